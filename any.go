@@ -0,0 +1,136 @@
+package assign
+
+import (
+	"reflect"
+	"sort"
+)
+
+// ofAny provides a Source of generic maps and slices, e.g. decoded JSON, without navigating by reflection.
+// Other values default to goSource.
+func ofAny(i interface{}) Source {
+	switch i.(type) {
+	case map[string]interface{}, []interface{}:
+		return &anySource{val: i}
+	}
+	return &goSource{val: valueOf(i)}
+}
+
+// anySource satisfies Source for map[string]interface{} and []interface{}.
+// The elements are navigated directly, only basic values are reflected to be assigned.
+type anySource struct {
+	val interface{}
+}
+
+func (v *anySource) Kind() reflect.Kind {
+	if _, ok := v.val.(map[string]interface{}); ok {
+		return reflect.Map
+	}
+	return reflect.Slice
+}
+
+// Elem is the source itself since generic maps and slices are not pointers or interfaces.
+func (v *anySource) Elem() Source {
+	return v
+}
+
+// FieldByName is invalid since generic maps and slices are not structs.
+func (v *anySource) FieldByName(string) Source {
+	return Of(nil)
+}
+
+func (v *anySource) Len() int {
+	switch val := v.val.(type) {
+	case map[string]interface{}:
+		return len(val)
+	case []interface{}:
+		return len(val)
+	}
+	return 0
+}
+
+func (v *anySource) Index(i int) Source {
+	return ofAny(v.val.([]interface{})[i])
+}
+
+func (v *anySource) Pointer() uintptr {
+	return reflect.ValueOf(v.val).Pointer()
+}
+
+func (v *anySource) MapRange() MapIter {
+	m := v.val.(map[string]interface{})
+	it := &anyMapIter{m: m, i: -1, keys: make([]string, 0, len(m))}
+	for key := range m {
+		it.keys = append(it.keys, key)
+	}
+	return it
+}
+
+func (v *anySource) Skip() bool {
+	switch val := v.val.(type) {
+	case map[string]interface{}:
+		return val == nil
+	case []interface{}:
+		return val == nil
+	}
+	return true
+}
+
+func (v *anySource) Interface() interface{} {
+	return v.val
+}
+
+var _ Source = (*anySource)(nil)
+
+// anyMapIter satisfies MapIter for map[string]interface{}.
+type anyMapIter struct {
+	m    map[string]interface{}
+	keys []string
+	i    int
+}
+
+func (it *anyMapIter) Next() bool {
+	it.i++
+	return it.i < len(it.keys)
+}
+
+func (it *anyMapIter) Key() Source {
+	return &goSource{val: reflect.ValueOf(it.keys[it.i])}
+}
+
+func (it *anyMapIter) Value() Source {
+	return ofAny(it.m[it.keys[it.i]])
+}
+
+// anyStruct satisfies Source for a map[string]interface{} assigned to a struct.
+// The map keys are the names of the struct fields.
+type anyStruct struct {
+	*anySource
+}
+
+func (v *anyStruct) Kind() reflect.Kind {
+	return reflect.Struct
+}
+
+func (v *anyStruct) FieldByName(name string) Source {
+	val, ok := v.val.(map[string]interface{})[name]
+	if !ok {
+		return Of(nil)
+	}
+	return ofAny(val)
+}
+
+// Fields provides the sorted keys of the map.
+func (v *anyStruct) Fields() []string {
+	m := v.val.(map[string]interface{})
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var (
+	_ Source  = (*anyStruct)(nil)
+	_ Fielder = (*anyStruct)(nil)
+)
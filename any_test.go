@@ -0,0 +1,182 @@
+package assign
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAssignAnySource(t *testing.T) {
+	t.Parallel()
+
+	tree := newCatalogTree(t, 10)
+	for _, options := range [][]Option{
+		{WithMapToStruct()},
+		{WithMapToStruct(), WithFieldNameNormalizer(strings.ToLower)},
+	} {
+		exp := Catalog{}
+		if err := ToFrom(&exp, &goSource{val: reflect.ValueOf(tree)}, options...); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		act := Catalog{}
+		if err := ToFrom(&act, tree, options...); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if diff := cmp.Diff(exp, act); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, act)
+		}
+		if len(act.Items) != 10 || act.Items[9].Tags["index"] != "9" {
+			t.Errorf("expected items to be assigned but found: %+v", act.Items)
+		}
+	}
+}
+
+func TestAssignAnySourceInterface(t *testing.T) {
+	t.Parallel()
+
+	src := map[string]interface{}{"a": []interface{}{1.0, "b", nil}}
+	dst := map[string]interface{}{}
+	if err := ToFrom(&dst, src); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if diff := cmp.Diff(src, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+}
+
+func TestAssignNestedTree(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		dst  interface{}
+		src  interface{}
+		exp  interface{}
+	}{
+		{
+			name: "map of slices of maps",
+			dst:  new(map[string][]map[string]int),
+			src: map[string]interface{}{
+				"a": []interface{}{map[string]interface{}{"x": 1}, map[string]interface{}{"y": 2}},
+				"b": []interface{}{},
+			},
+			exp: map[string][]map[string]int{
+				"a": {{"x": 1}, {"y": 2}},
+				"b": {},
+			},
+		},
+		{
+			name: "slice of maps of slices",
+			dst:  new([]map[string][]int),
+			src: []interface{}{
+				map[string]interface{}{"a": []interface{}{1, 2}},
+				map[string]interface{}{"b": []interface{}{3}},
+			},
+			exp: []map[string][]int{{"a": {1, 2}}, {"b": {3}}},
+		},
+		{
+			name: "typed leaves",
+			dst:  new(map[string][]map[string][]float64),
+			src: map[string]interface{}{
+				"a": []interface{}{map[string]interface{}{"x": []interface{}{1, 2.5}}},
+			},
+			exp: map[string][]map[string][]float64{"a": {{"x": {1, 2.5}}}},
+		},
+		{
+			name: "structs",
+			dst:  new(map[string][]Catalog),
+			src: map[string]interface{}{
+				"a": []interface{}{map[string]interface{}{
+					"Name":  "catalog",
+					"Items": []interface{}{map[string]interface{}{"ID": 1, "Tags": map[string]interface{}{"k": "v"}}},
+				}},
+			},
+			exp: map[string][]Catalog{"a": {{Name: "catalog", Items: []CatalogItem{{ID: 1, Tags: map[string]string{"k": "v"}}}}}},
+		},
+		{
+			name: "go values",
+			dst:  new(map[string][]map[string]int),
+			src:  map[string][]map[string]int64{"a": {{"x": 1}}},
+			exp:  map[string][]map[string]int{"a": {{"x": 1}}},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			if err := ToFrom(test.dst, test.src, WithMapToStruct()); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			act := reflect.ValueOf(test.dst).Elem().Interface()
+			if diff := cmp.Diff(test.exp, act); diff != "" {
+				t.Errorf("(-expected +actual):\n%s\n%+v", diff, act)
+			}
+		})
+	}
+}
+
+func BenchmarkAssignAnySource(b *testing.B) {
+	tree := newCatalogTree(b, 1000)
+	b.Run("reflect", func(b *testing.B) {
+		b.ReportAllocs()
+		src := &goSource{val: reflect.ValueOf(tree)}
+		for i := 0; i < b.N; i++ {
+			dst := Catalog{}
+			if err := ToFrom(&dst, src, WithMapToStruct()); err != nil {
+				b.Fatalf("unexpected error: %v", err)
+			}
+		}
+	})
+	b.Run("any", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			dst := Catalog{}
+			if err := ToFrom(&dst, tree, WithMapToStruct()); err != nil {
+				b.Fatalf("unexpected error: %v", err)
+			}
+		}
+	})
+}
+
+// newCatalogTree provides a generic tree of a catalog decoded from JSON.
+func newCatalogTree(tb testing.TB, n int) map[string]interface{} {
+	tb.Helper()
+	items := make([]CatalogItem, n)
+	for i := range items {
+		items[i] = CatalogItem{
+			ID:    i,
+			Name:  "item " + strconv.Itoa(i),
+			Price: float64(i) / 2,
+			Tags:  map[string]string{"index": strconv.Itoa(i)},
+		}
+	}
+	b, err := json.Marshal(Catalog{Name: "catalog", Items: items})
+	if err != nil {
+		tb.Fatalf("unexpected error: %v", err)
+	}
+	tree := map[string]interface{}{}
+	if err := json.Unmarshal(b, &tree); err != nil {
+		tb.Fatalf("unexpected error: %v", err)
+	}
+	return tree
+}
+
+type Catalog struct {
+	Name  string
+	Items []CatalogItem
+}
+
+type CatalogItem struct {
+	ID    int
+	Name  string
+	Price float64
+	Tags  map[string]string
+}
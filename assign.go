@@ -2,7 +2,19 @@
 package assign
 
 import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"path"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 // ToFrom assigns a Source value to the given Go value with options.
@@ -13,27 +25,136 @@ func ToFrom(dst, src interface{}, options ...Option) error {
 
 // Assigner assigns values of any source to Go values.
 type Assigner struct {
-	src   Source
-	tags  []string
-	cycle bool
+	src        Source
+	tags       []string
+	cycle      bool
+	mapIndexed bool
+	mapStructs bool
+	appendList bool
+	recursion  int
+	unexported bool
+	enums      enums
+	ifaceTypes map[string]reflect.Type
+	// timeLayouts are the layouts to parse time.Time from strings.
+	timeLayouts []string
+	unixTime    time.Duration
+	mapTypes    []mapType
+	strTrans    []func(string) string
+	dotted      bool
+	discField   string
+	discTypes   map[string]reflect.Type
+	durations   bool
+	sharing     bool
+	getters     bool
+	mapFilters  []func(key interface{}) bool
+	chanFill    bool
+	chanBlock   bool
+	reuseList   bool
+	aggregate   bool
+	normalizers []func(string) string
+	overflow    bool
+	required    bool
+	inferIface  bool
+	progress    func(assigned, total int)
+	changed     bool
+	original    reflect.Value
+	valueTrans  []func(path string, dst reflect.Type, src interface{}) (interface{}, bool)
+	base64      bool
+	composites  map[string]func(Source) (interface{}, error)
+	ifaceReuse  bool
+	structMaps  bool
+	flatten     string
+	binary      bool
+	converters  map[reflect.Type]func(src interface{}) (interface{}, error)
+	zeroDst     bool
+	cycleSkip   bool
+	alloc       func(t reflect.Type) reflect.Value
+	omitEmpty   bool
+	unmatched   func(name string)
+	rawMessage  bool
+	maxErrors   int
+	structLists bool
+	listStructs bool
+	// generic forces the general path of assignStruct, see assignScalars.
+	generic bool
+	// tagKeys are the tag keys joined, marked when following JSON conventions, to cache the fields by tags.
+	tagKeys       string
+	orderedMaps   bool
+	srcTags       []string
+	intStrict     bool
+	scalarList    bool
+	presence      bool
+	maxElements   int
+	errStrings    bool
+	fieldOrder    func(a, b reflect.StructField) bool
+	bitFlags      map[string]int
+	tagTrans      []func(string) string
+	skipEqual     bool
+	maxString     int
+	truncString   bool
+	pathMaps      []pathMapping
+	resultBuf     reflect.Value
+	timeLoc       *time.Location
+	tagCache      bool
+	strictIndices bool
+	jsonTags      bool
 }
 
 // From creates a new Assigner from the given source and options.
 // The Source value is determined by the Of function from source.
-// By default, the `assign` tag is used and cyclical path checks are enabled.
+// By default, the `assign` tag is used, cyclical path checks are enabled
+// and time.Time is parsed from strings in the RFC 3339 format.
 // See Option to change the defaults.
+// The package defaults of SetDefaultTags and SetDefaultOptions are applied before the options.
 func From(src interface{}, options ...Option) *Assigner {
 	a := &Assigner{
-		src:   Of(src),
-		tags:  []string{"assign"},
-		cycle: true,
+		src:         Of(src),
+		tags:        []string{"assign"},
+		cycle:       true,
+		timeLayouts: []string{time.RFC3339},
 	}
+	defaults.RLock()
+	a.tags = append(a.tags, defaults.tags...)
+	for _, option := range defaults.options {
+		option(a)
+	}
+	defaults.RUnlock()
 	for _, option := range options {
 		option(a)
 	}
+	a.tagKeys = strings.Join(a.tags, ",")
+	if a.jsonTags {
+		a.tagKeys += ",json-"
+	}
 	return a
 }
 
+// defaults are the package defaults applied to each new Assigner.
+var defaults struct {
+	sync.RWMutex
+	tags    []string
+	options []Option
+}
+
+// SetDefaultTags sets the tag keys appended for each new Assigner, as with WithTags.
+// This replaces the tag keys of previous calls, call without tags to reset.
+// This is useful for codebases that standardize on a tag, e.g. SetDefaultTags("json").
+// It is safe to call concurrently with assignments.
+func SetDefaultTags(tags ...string) {
+	defaults.Lock()
+	defer defaults.Unlock()
+	defaults.tags = append([]string(nil), tags...)
+}
+
+// SetDefaultOptions sets the options applied to each new Assigner before the options given to From.
+// This replaces the options of previous calls, call without options to reset.
+// It is safe to call concurrently with assignments.
+func SetDefaultOptions(options ...Option) {
+	defaults.Lock()
+	defer defaults.Unlock()
+	defaults.options = append([]Option(nil), options...)
+}
+
 // To assigns the Source to the given Go value.
 // The Go value can be any supported type but must be a pointer that is not nil
 // or a reflect.Value of a pointer that is not nil.
@@ -42,26 +163,193 @@ func From(src interface{}, options ...Option) *Assigner {
 // A Go value may be partially assigned when an error occurs.
 // See error.go for error type details.
 func (a *Assigner) To(dst interface{}) error {
+	_, err := a.ToStats(dst)
+	return err
+}
+
+// Stats are the statistics of an assignment.
+type Stats struct {
+	// Assigned is the number of basic values assigned.
+	Assigned int
+	// Skipped is the number of values skipped,
+	// e.g. zero source values or destination fields that are not exported.
+	Skipped int
+	// Errored is the number of values that failed to assign.
+	Errored int
+	// MaxDepth is the deepest destination path reached,
+	// counting each struct field, list element and map value as a level.
+	MaxDepth int
+	// Cycles are the destination paths of cyclical sources skipped with the WithCycleSkip option.
+	Cycles []string
+	// Truncated is the number of values cut short by a limit,
+	// i.e. strings truncated with the WithStringTruncation option
+	// and the assignment once an error is dropped by the limit of the WithMaxErrors option.
+	Truncated int
+}
+
+// ToStats assigns the Source to the given Go value and provides statistics of the assignment.
+// See To for additional details.
+func (a *Assigner) ToStats(dst interface{}) (Stats, error) {
 	dv := valueOf(dst)
 	if dv.Kind() != reflect.Ptr {
-		return newError(dv.Type(), a.src.Kind())
+		return Stats{}, newError(dv.Type(), a.src.Kind())
 	}
 	if dv.IsNil() {
-		return newError(reflect.TypeOf(nil), a.src.Kind())
+		return Stats{}, newError(reflect.TypeOf(nil), a.src.Kind())
 	}
-	var md *metadata
+	if a.zeroDst {
+		de := dv.Elem()
+		de.Set(reflect.Zero(de.Type()))
+	}
+	md := &metadata{orig: a.original}
 	if a.cycle {
-		md = &metadata{visited: map[uintptr]struct{}{
+		md.visited = map[uintptr]struct{}{
 			dv.Pointer(): {},
-		}}
+		}
 	}
-	return a.assignRecover(dv.Elem(), md)
+	err := a.assignRecover(dv.Elem(), md)
+	return md.stats, err
 }
 
-// metadata is used to track cyclical paths.
+// metadata is used to track cyclical paths, the destination path and statistics.
 type metadata struct {
 	visited map[uintptr]struct{}
 	cur     uintptr
+	// types counts the composite destination types of the current path.
+	types map[reflect.Type]int
+	// segs are the segments of the current destination path, see path.
+	segs  []segment
+	depth int
+	stats Stats
+	// iface is the concrete type of the next interface destination, see interfaceType.
+	iface reflect.Type
+	// errs are the errors collected with the WithErrorAggregation option.
+	errs []error
+	// paths are the destination paths of the collected errors by index.
+	paths []string
+	// truncated is when an error is dropped by the limit of the WithMaxErrors option.
+	truncated bool
+	// progressed is the number of elements of the root source assigned, see WithProgress.
+	progressed int
+	// orig is the original value of the current path, see WithChangedOnly.
+	orig reflect.Value
+	// elements is the number of elements of maps and lists assigned, see WithMaxElements.
+	elements int
+}
+
+// segment is a field, index or key of the destination path.
+// The index and key are formatted when the path is built, see metadata.path.
+type segment struct {
+	kind  segmentKind
+	name  string
+	index int
+	key   reflect.Value
+}
+
+// segmentKind is the kind of a segment of the destination path.
+type segmentKind uint8
+
+const (
+	fieldSegment segmentKind = iota
+	nameSegment
+	indexSegment
+	keySegment
+)
+
+// path builds the current destination path, e.g. Field.Slice[0].Map[key].
+// The root destination has an empty path.
+// Paths are built as they are needed, e.g. by errors and options of paths,
+// so the segments are not formatted on every assignment.
+func (md *metadata) path() string {
+	path := ""
+	for _, seg := range md.segs {
+		switch seg.kind {
+		case fieldSegment:
+			path = fieldPath(path, seg.name)
+		case nameSegment:
+			path = indexPath(path, seg.name)
+		case indexSegment:
+			path = indexPath(path, strconv.Itoa(seg.index))
+		case keySegment:
+			path = indexPath(path, fmt.Sprint(seg.key.Interface()))
+		}
+	}
+	return path
+}
+
+// root reports whether the current destination is the root destination.
+func (md *metadata) root() bool {
+	return len(md.segs) == 0
+}
+
+// frame is the position of the destination to restore after assigning its children.
+type frame struct {
+	segs  int
+	depth int
+	orig  reflect.Value
+}
+
+// save provides the current frame.
+func (md *metadata) save() frame {
+	return frame{segs: len(md.segs), depth: md.depth, orig: md.orig}
+}
+
+// restore sets the current frame.
+func (md *metadata) restore(f frame) {
+	md.segs = md.segs[:f.segs]
+	md.depth = f.depth
+	md.orig = f.orig
+}
+
+// field descends from the frame to the named field.
+func (md *metadata) field(f frame, name string) {
+	md.segs = append(md.segs[:f.segs], segment{kind: fieldSegment, name: name})
+	md.orig = originalField(f.orig, name)
+	md.descend(f)
+}
+
+// index descends from the frame to the key by name.
+// The original value is reset, see originalIndex and originalKey.
+func (md *metadata) index(f frame, key string) {
+	md.descendTo(f, segment{kind: nameSegment, name: key})
+}
+
+// indexAt descends from the frame to the list index.
+func (md *metadata) indexAt(f frame, i int) {
+	md.descendTo(f, segment{kind: indexSegment, index: i})
+}
+
+// key descends from the frame to the map key.
+func (md *metadata) key(f frame, key reflect.Value) {
+	md.descendTo(f, segment{kind: keySegment, key: key})
+}
+
+// descendTo descends from the frame to the index or key segment.
+func (md *metadata) descendTo(f frame, seg segment) {
+	md.segs = append(md.segs[:f.segs], seg)
+	md.orig = reflect.Value{}
+	md.descend(f)
+}
+
+// fieldPath joins the path and the field name with a dot.
+func fieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// indexPath joins the path and the index or key in brackets.
+func indexPath(path, key string) string {
+	return path + "[" + key + "]"
+}
+
+// descend sets the depth below the frame.
+func (md *metadata) descend(f frame) {
+	md.depth = f.depth + 1
+	if md.depth > md.stats.MaxDepth {
+		md.stats.MaxDepth = md.depth
+	}
 }
 
 // assignRecover recovers unexpected assign panics.
@@ -71,8 +359,22 @@ func (a *Assigner) assignRecover(dv reflect.Value, md *metadata) (err error) {
 		if rec := recover(); rec != nil {
 			err = ErrorPanic{Rec: rec}
 		}
+		if a.aggregate {
+			if err != nil && !md.truncated {
+				md.errs = append(md.errs, err)
+				md.paths = append(md.paths, md.path())
+			}
+			err = nil
+			if len(md.errs) > 0 {
+				err = ErrorMultiple{Errors: md.errs, Paths: md.paths, Truncated: md.truncated}
+			}
+		}
 	}()
 
+	if a.pathMaps != nil {
+		err = a.assignPaths(dv, md)
+		return
+	}
 	err = a.assign(dv, a.src, md)
 	return
 }
@@ -81,21 +383,144 @@ func (a *Assigner) assignRecover(dv reflect.Value, md *metadata) (err error) {
 func (a *Assigner) assign(dv reflect.Value, sv Source, md *metadata) error {
 	// CanSet of dst handles fields that are not exported.
 	// Skip of src handles invalid or zero values.
+	// Values of src obtained from fields that are not exported are ignored as well, see canInterface.
 	// All these cases are expected to be ignored without assignment.
-	if !dv.CanSet() || sv.Skip() {
+	// Fields that are not exported are made settable with the WithUnsafeUnexported option.
+	if a.unexported {
+		dv = unexported(dv)
+	}
+	if !dv.CanSet() || sv.Skip() || !a.canInterface(sv) {
+		md.stats.Skipped++
 		return nil
 	}
+	// Errors are counted where they occur, not as they are returned by parents.
+	errored := md.stats.Errored
+	err := a.assignValue(dv, sv, md)
+	if err != nil && md.stats.Errored == errored {
+		md.stats.Errored++
+	}
+	return a.collect(err, md)
+}
+
+// collect collects the error with the WithErrorAggregation option
+// where it occurs so the parents continue with the remaining values.
+// Once an error is dropped by the limit of the WithMaxErrors option,
+// errTruncated is returned through the parents to abort the assignment.
+func (a *Assigner) collect(err error, md *metadata) error {
+	if err == nil || !a.aggregate || md.truncated {
+		return err
+	}
+	if a.maxErrors > 0 && len(md.errs) >= a.maxErrors {
+		md.truncated = true
+		md.stats.Truncated++
+		return errTruncated
+	}
+	md.errs = append(md.errs, err)
+	md.paths = append(md.paths, md.path())
+	return nil
+}
+
+// errTruncated aborts the assignment once an error exceeds the limit of the WithMaxErrors option.
+// It is not collected, ErrorMultiple.Truncated is set instead.
+var errTruncated = errors.New("assign: errors truncated")
+
+// assignValue assigns to a value that is not skipped.
+func (a *Assigner) assignValue(dv reflect.Value, sv Source, md *metadata) error {
 	// The visit logic of source handles circular paths.
 	if a.visit(sv, md) {
+		if a.cycleSkip {
+			md.stats.Skipped++
+			md.stats.Cycles = append(md.stats.Cycles, md.path())
+			return nil
+		}
 		return ErrorCycle{
 			Dst: dv.Type(),
 			Src: sv.Kind(),
 		}
 	}
 
+	if a.sharing && sv.Kind() == reflect.Ptr && dv.Kind() == reflect.Ptr {
+		if sp := a.valueOf(sv); sp.Type() == dv.Type() {
+			dv.Set(sp)
+			md.stats.Assigned++
+			return nil
+		}
+	}
+	if u, ok := assignableOf(dv); ok {
+		return a.assignAssignable(u, sv, md)
+	}
+	if a.skipEqual && a.equal(dv, sv) {
+		md.stats.Skipped++
+		return nil
+	}
+	if a.presence && sv.Kind() == reflect.Ptr {
+		if se := sv.Elem(); se.Kind() != reflect.Invalid && se.Skip() {
+			return a.assignZero(dv, md)
+		}
+	}
+	if a.errStrings {
+		if ok, err := a.assignErrorString(dv, sv, md); ok {
+			return err
+		}
+	}
 	if _, ok := elemSet[sv.Kind()]; ok {
 		return a.assign(dv, sv.Elem(), md)
 	}
+	if fn, ok := a.converters[dv.Type()]; ok {
+		return a.assignConverted(dv, sv, fn, md)
+	}
+	if a.rawMessage && dv.Type() == rawMessageType {
+		return a.assignRawMessage(dv, sv, md)
+	}
+	if typ := a.interfaceType(md); typ != nil && dv.Kind() == reflect.Interface {
+		return a.assignInterface(dv, sv, typ, md)
+	}
+	if a.discTypes != nil && dv.Kind() == reflect.Interface {
+		if typ := a.discriminatedType(sv); typ != nil {
+			return a.assignInterface(dv, sv, typ, md)
+		}
+	}
+	if a.ifaceReuse && dv.Kind() == reflect.Interface && !dv.IsNil() {
+		return a.assignExisting(dv, sv, md)
+	}
+	if a.inferIface && dv.Kind() == reflect.Interface && dv.NumMethod() == 0 {
+		if typ := a.inferType(sv); typ != nil {
+			return a.assignInterface(dv, sv, typ, md)
+		}
+	}
+	if _, ok := bigSet[dv.Type()]; ok {
+		return a.assignToBig(dv, sv, md)
+	}
+	if dv.Type() == timeType {
+		return a.assignTime(dv, sv, md)
+	}
+	if dv.Type() == syncMapType {
+		return a.assignSyncMap(dv, sv, md)
+	}
+	if dv.Type() == orderedMapType {
+		return a.assignOrderedMap(dv, sv, md)
+	}
+	if a.binary && sv.Kind() == reflect.Slice && isBytes(a.valueOf(sv).Type()) {
+		if u, ok := binaryUnmarshaler(dv); ok {
+			return a.assignBinary(u, dv, sv, md)
+		}
+	}
+	if a.bitFlags != nil {
+		if ok, err := a.assignBitFlags(dv, sv, md); ok {
+			return err
+		}
+	}
+	// The recurse logic of destination handles recursive types.
+	if _, ok := compositeSet[dv.Kind()]; ok && a.recursion > 0 {
+		dt := dv.Type()
+		if !md.recurse(dt, a.recursion) {
+			return ErrorCycle{
+				Dst: dt,
+				Src: sv.Kind(),
+			}
+		}
+		defer md.unrecurse(dt)
+	}
 
 	switch dk := dv.Kind(); dk {
 	case reflect.Ptr:
@@ -108,116 +533,873 @@ func (a *Assigner) assign(dv reflect.Value, sv Source, md *metadata) error {
 		return a.assignSlice(dv, sv, md)
 	case reflect.Array:
 		return a.assignArray(dv, sv, md)
+	case reflect.Chan:
+		if a.chanFill {
+			return a.assignChan(dv, sv, md)
+		}
+		return a.assignBasic(dv, sv, md)
 	default:
-		return a.assignBasic(dv, sv)
+		return a.assignBasic(dv, sv, md)
 	}
 }
 
 // assignPointer assigns to a pointer.
+// Each level of a nil pointer is allocated through recursion,
+// while each level of a source pointer is dereferenced by assign.
 func (a *Assigner) assignPointer(dp reflect.Value, sp Source, md *metadata) error {
 	if dp.IsNil() {
-		dp.Set(reflect.New(dp.Type().Elem()))
+		dp.Set(a.newValue(dp.Type().Elem()).Addr())
 	}
 	return a.assign(dp.Elem(), sp, md)
 }
 
+// assignZero assigns the zero value to the destination for a source pointer to a zero value, see WithPointerPresence.
+// Destination pointers are allocated to point to the zero value.
+func (a *Assigner) assignZero(dv reflect.Value, md *metadata) error {
+	for dv.Kind() == reflect.Ptr {
+		if dv.IsNil() {
+			dv.Set(a.newValue(dv.Type().Elem()).Addr())
+		}
+		dv = dv.Elem()
+	}
+	dv.Set(reflect.Zero(dv.Type()))
+	md.stats.Assigned++
+	return nil
+}
+
+// newValue provides a settable zero value of the type for a new element of the destination.
+// The allocator of the WithAllocator option is used when given.
+func (a *Assigner) newValue(t reflect.Type) reflect.Value {
+	if a.alloc != nil {
+		return a.alloc(t)
+	}
+	return reflect.New(t).Elem()
+}
+
+// interfaceType provides the concrete type to assign to the current interface destination
+// or nil when there is none.
+// The concrete type of a map value is only provided to the map value itself, not its children.
+func (a *Assigner) interfaceType(md *metadata) reflect.Type {
+	if typ := md.iface; typ != nil {
+		md.iface = nil
+		return typ
+	}
+	if len(a.ifaceTypes) == 0 {
+		return nil
+	}
+	return a.ifaceTypes[md.path()]
+}
+
+// discriminatedType provides the concrete type registered by the discriminator field of the source
+// or nil when there is none.
+func (a *Assigner) discriminatedType(sv Source) reflect.Type {
+	var sf Source
+	switch sv.Kind() {
+	case reflect.Map:
+		sf = newMapStruct(sv, false).FieldByName(a.discField)
+	case reflect.Struct:
+		sf = sv.FieldByName(a.discField)
+	default:
+		return nil
+	}
+	if sf.Skip() {
+		return nil
+	}
+	for sf.Kind() == reflect.Interface || sf.Kind() == reflect.Ptr {
+		if sf = sf.Elem(); sf.Skip() {
+			return nil
+		}
+	}
+	return a.discTypes[fmt.Sprint(sf.Interface())]
+}
+
+// mapValueType provides the concrete type of the first pattern matching the map key
+// or nil when there is none.
+func (a *Assigner) mapValueType(dk reflect.Value) reflect.Type {
+	if len(a.mapTypes) == 0 {
+		return nil
+	}
+	key := fmt.Sprint(dk.Interface())
+	for _, mt := range a.mapTypes {
+		if ok, _ := path.Match(mt.pattern, key); ok {
+			return mt.typ
+		}
+	}
+	return nil
+}
+
+// mapType is the concrete type of map values with keys matching the pattern.
+type mapType struct {
+	pattern string
+	typ     reflect.Type
+}
+
+// assignInterface assigns to an interface with a value of the concrete type.
+// The concrete value is assigned before it is boxed into the interface.
+func (a *Assigner) assignInterface(di reflect.Value, si Source, typ reflect.Type, md *metadata) error {
+	if !typ.Implements(di.Type()) {
+		return newError(di.Type(), si.Kind())
+	}
+	dv := a.newValue(typ)
+	if err := a.assign(dv, si, md); err != nil {
+		return err
+	}
+	di.Set(dv)
+	return nil
+}
+
+// assignExisting assigns to the concrete value held by an interface.
+// Pointers that are not nil are assigned in place, other values are copied, assigned and then boxed again.
+func (a *Assigner) assignExisting(di reflect.Value, si Source, md *metadata) error {
+	cur := di.Elem()
+	if cur.Kind() == reflect.Ptr && !cur.IsNil() {
+		return a.assign(cur.Elem(), si, md)
+	}
+	dv := a.newValue(cur.Type())
+	dv.Set(cur)
+	if err := a.assign(dv, si, md); err != nil {
+		return err
+	}
+	di.Set(dv)
+	return nil
+}
+
+// assignConverted assigns the value of the converter registered for the destination type.
+// The converted value must be convertible to the destination type.
+// The error of the converter is returned as ErrorConvert.
+func (a *Assigner) assignConverted(dv reflect.Value, sv Source, fn func(interface{}) (interface{}, error), md *metadata) error {
+	dt := dv.Type()
+	src := a.valueOf(sv).Interface()
+	v, err := fn(src)
+	if err != nil {
+		return newErrorConvert(dt, src, err)
+	}
+	cv := reflect.Zero(dt)
+	if v != nil {
+		rv := reflect.ValueOf(v)
+		if !rv.Type().ConvertibleTo(dt) {
+			return newError(dt, rv.Kind())
+		}
+		cv = rv.Convert(dt)
+	}
+	dv.Set(cv)
+	md.stats.Assigned++
+	return nil
+}
+
+// inferType provides the concrete type of an empty interface by the kind of the source.
+// Maps are map[string]interface{}, or map[interface{}]interface{} when the keys are not strings.
+// Slices and arrays are []interface{} and basic values are the basic type of their kind.
+// Go structs keep their type as structs have no generic type.
+// Nil is provided for sources that have no inferred type.
+func (a *Assigner) inferType(sv Source) reflect.Type {
+	switch sk := sv.Kind(); sk {
+	case reflect.Map:
+		if mi := sv.MapRange(); mi.Next() && mi.Key().Kind() != reflect.String {
+			return anyMapType
+		}
+		return stringMapType
+	case reflect.Slice, reflect.Array:
+		return anySliceType
+	case reflect.Struct:
+		if gs, ok := sv.(*goSource); ok {
+			return gs.val.Type()
+		}
+		return nil
+	default:
+		return kindTypes[sk]
+	}
+}
+
 // assignBasic assigns to a basic value.
-func (a *Assigner) assignBasic(db reflect.Value, sb Source) error {
-	sv := reflect.ValueOf(sb.Interface())
+func (a *Assigner) assignBasic(db reflect.Value, sb Source, md *metadata) error {
+	sv := a.valueOf(sb)
 	dt := db.Type()
+	if ok, err := a.transformValue(db, sv, md); ok {
+		return err
+	}
+	if _, ok := bigSet[sv.Type()]; ok && isBigNumeric(dt.Kind()) {
+		return a.assignFromBig(db, sv, md)
+	}
+	if ok, err := a.assignEnum(db, sv, md); ok {
+		return err
+	}
+	if sv.Type() == jsonNumberType {
+		if ok, err := a.assignJSONNumber(db, sv, md); ok {
+			return err
+		}
+	}
+	if dt == durationType && sv.Kind() == reflect.String && a.durations {
+		return a.assignDuration(db, sv, md)
+	}
+	if dt.Kind() == reflect.String && a.base64 && isBytes(sv.Type()) {
+		sv = reflect.ValueOf(base64.StdEncoding.EncodeToString(sv.Bytes()))
+	}
 	if st := sv.Type(); !st.ConvertibleTo(dt) {
 		return newError(dt, st.Kind())
 	}
-	db.Set(sv.Convert(dt))
+	if a.overflow && overflows(dt, sv) {
+		return newErrorOverflow(dt, sv.Interface())
+	}
+	if a.intStrict && fractional(dt, sv) {
+		return newError(dt, sv.Kind())
+	}
+	cv := sv.Convert(dt)
+	if dt.Kind() == reflect.String && a.maxString > 0 && cv.Len() > a.maxString {
+		if !a.truncString {
+			return newErrorLimit(dt, a.maxString)
+		}
+		cv = truncate(cv, a.maxString)
+		md.stats.Truncated++
+	}
+	if dt.Kind() == reflect.String && len(a.strTrans) > 0 {
+		cv = a.transformString(cv)
+	}
+	if a.unchanged(cv, md) {
+		md.stats.Skipped++
+		return nil
+	}
+	db.Set(cv)
+	md.stats.Assigned++
 	return nil
 }
 
+// truncate slices the string value to at most n bytes without splitting a rune.
+func truncate(sv reflect.Value, n int) reflect.Value {
+	s := sv.String()
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return sv.Slice(0, n)
+}
+
+// transformString transforms the string value with each string transform in order.
+func (a *Assigner) transformString(sv reflect.Value) reflect.Value {
+	s := sv.String()
+	for _, fn := range a.strTrans {
+		s = fn(s)
+	}
+	return reflect.ValueOf(s).Convert(sv.Type())
+}
+
+// transformValue assigns the value of the first value transform that handles it.
+// The value is converted to the destination, nil is the zero value of the destination.
+// The handled result is false when no value transform handles the value.
+func (a *Assigner) transformValue(db, sv reflect.Value, md *metadata) (bool, error) {
+	if len(a.valueTrans) == 0 || !sv.CanInterface() {
+		return false, nil
+	}
+	dt := db.Type()
+	src := sv.Interface()
+	path := md.path()
+	for _, fn := range a.valueTrans {
+		v, ok := fn(path, dt, src)
+		if !ok {
+			continue
+		}
+		cv := reflect.Zero(dt)
+		if v != nil {
+			rv := reflect.ValueOf(v)
+			if !rv.Type().ConvertibleTo(dt) {
+				return true, newError(dt, rv.Kind())
+			}
+			cv = rv.Convert(dt)
+		}
+		if a.unchanged(cv, md) {
+			md.stats.Skipped++
+			return true, nil
+		}
+		db.Set(cv)
+		md.stats.Assigned++
+		return true, nil
+	}
+	return false, nil
+}
+
+// overflows reports whether the number overflows the numeric type.
+// Negative integers overflow unsigned types and unsigned integers above the signed maximum overflow signed types,
+// even for types of the same width.
+func overflows(dt reflect.Type, sv reflect.Value) bool {
+	dv := reflect.Zero(dt)
+	dk := dt.Kind()
+	switch sv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := sv.Int()
+		if _, ok := intSet[dk]; ok {
+			return dv.OverflowInt(n)
+		}
+		if _, ok := uintSet[dk]; ok {
+			return n < 0 || dv.OverflowUint(uint64(n))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n := sv.Uint()
+		if _, ok := intSet[dk]; ok {
+			return n > math.MaxInt64 || dv.OverflowInt(int64(n))
+		}
+		if _, ok := uintSet[dk]; ok {
+			return dv.OverflowUint(n)
+		}
+	case reflect.Float32, reflect.Float64:
+		if dk == reflect.Float32 || dk == reflect.Float64 {
+			return dv.OverflowFloat(sv.Float())
+		}
+	}
+	return false
+}
+
+// fractional reports whether the float value has a fractional part for an integer type.
+// Values that are not finite are fractional as they are not integers.
+func fractional(dt reflect.Type, sv reflect.Value) bool {
+	if sk := sv.Kind(); sk != reflect.Float32 && sk != reflect.Float64 {
+		return false
+	}
+	if dk := dt.Kind(); !isKind(intSet, dk) && !isKind(uintSet, dk) {
+		return false
+	}
+	f := sv.Float()
+	return math.IsInf(f, 0) || math.IsNaN(f) || f != math.Trunc(f)
+}
+
 // assignStruct assigns to a struct.
 func (a *Assigner) assignStruct(ds reflect.Value, ss Source, md *metadata) error {
 	dt := ds.Type()
+	if ss.Kind() == reflect.Map && a.mapStructs {
+		ss = newMapStruct(ss, a.dotted)
+	}
+	if _, ok := listSet[ss.Kind()]; ok && a.listStructs {
+		ss = a.newListStruct(ss, dt)
+	}
 	if sk := ss.Kind(); sk != reflect.Struct {
 		return newError(dt, sk)
 	}
+	if a.scalars() {
+		if fields, ok := a.scalarFieldsOf(dt); ok {
+			return a.assignScalars(ds, ss, fields, md)
+		}
+	}
+	names := a.normalizedFields(ss)
+
+	f := md.save()
+	defer md.restore(f)
+
 	n := ds.NumField()
-	for i := 0; i < n; i++ {
+	order := a.fieldOrdered(dt)
+	tags := a.tagsOf(dt)
+	for k := 0; k < n; k++ {
+		i := k
+		if order != nil {
+			i = order[k]
+		}
 		df := ds.Field(i)
-		dn := a.nameOf(dt.Field(i))
-		sf := ss.FieldByName(dn)
-		if err := a.assign(df, sf, md); err != nil {
+		dsf := dt.Field(i)
+		var tag fieldTag
+		if tags != nil {
+			tag = tags[i]
+		} else {
+			tag = a.tagOf(dsf)
+		}
+		if tag.ignored {
+			md.stats.Skipped++
+			continue
+		}
+		dn := tag.name
+		if sn, ok := names[a.normalize(dn)]; ok && ss.FieldByName(dn).Kind() == reflect.Invalid {
+			dn = sn
+		}
+		md.field(f, dsf.Name)
+		sf, err := a.compositeField(ss, md)
+		if sf == nil && err == nil {
+			sf, err = a.fieldByName(ss, dn)
+		}
+		if err != nil {
 			return err
 		}
+		if a.unmatched != nil && sf.Kind() == reflect.Invalid {
+			a.unmatched(md.path())
+		}
+		errored := md.stats.Errored
+		if err := a.assignField(df, sf, tag, dt, dn, md); err != nil {
+			return err
+		}
+		// Only fields set without errors are validated, not those absent from the source.
+		if md.stats.Errored != errored || !fieldSet(sf, tag) {
+			continue
+		}
+		if err := validate(df, tag, md); err != nil {
+			md.stats.Errored++
+			if err := a.collect(err, md); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
 
+// fieldSet reports whether the field is set by the source or the default of its tag.
+func fieldSet(sf Source, tag fieldTag) bool {
+	if sf.Kind() != reflect.Invalid {
+		return true
+	}
+	_, ok := tag.value("default")
+	return ok
+}
+
+// fieldOrdered provides the indices of the fields of the struct type sorted stably by the WithFieldOrder option.
+// The indices are nil without the option as fields are in the order of declaration.
+func (a *Assigner) fieldOrdered(dt reflect.Type) []int {
+	if a.fieldOrder == nil {
+		return nil
+	}
+	n := dt.NumField()
+	indices := make([]int, n)
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		return a.fieldOrder(dt.Field(indices[i]), dt.Field(indices[j]))
+	})
+	return indices
+}
+
+// assignField assigns the field of the struct by the options of its tag,
+// e.g. defaults, required fields and directives.
+func (a *Assigner) assignField(df reflect.Value, sf Source, tag fieldTag, dt reflect.Type, dn string, md *metadata) error {
+	if sf.Skip() {
+		if def, ok := tag.value("default"); ok {
+			return a.assignDefault(df, def, md)
+		}
+		if a.required && tag.has("required") {
+			return newErrorMissingField(dt, dn, md.path())
+		}
+	}
+	if len(tag.opts) > 0 && !sf.Skip() {
+		if ok, err := a.assignDirective(df, sf, tag, md); ok {
+			return err
+		}
+	}
+	return a.assign(df, sf, md)
+}
+
 // assignMap assigns to a map.
 func (a *Assigner) assignMap(dm reflect.Value, sm Source, md *metadata) error {
 	dt := dm.Type()
+	if sm.Kind() == reflect.Struct && a.structMaps {
+		return a.assignFromStruct(dm, sm, md)
+	}
 	if sk := sm.Kind(); sk != reflect.Map {
 		return newError(dt, sk)
 	}
 	kt := dt.Key()
 	vt := dt.Elem()
 	if dm.IsNil() {
-		dm.Set(reflect.MakeMapWithSize(dt, sm.Len()))
+		dm.Set(reflect.MakeMapWithSize(dt, a.sizeHint(sm.Len(), md)))
 	}
 
+	f := md.save()
+	defer md.restore(f)
+
+	total := sm.Len()
 	for mi := sm.MapRange(); mi.Next(); {
-		dk := reflect.New(kt).Elem()
+		if err := a.element(dt, md); err != nil {
+			return err
+		}
+		dk := a.newValue(kt)
 		sk := mi.Key()
+		md.restore(f)
 		if err := a.assign(dk, sk, md); err != nil {
 			return err
 		}
-		dv := reflect.New(vt).Elem()
+		// Keys of interface types may hold values that are not hashable.
+		if !dk.Comparable() {
+			return newErrorUnhashableKey(dt, dk.Elem().Type())
+		}
+		if !a.includeKey(dk) {
+			a.progressed(f, total, md)
+			continue
+		}
+		dv := a.newValue(vt)
 		sv := mi.Value()
-		if err := a.assign(dv, sv, md); err != nil {
+		md.key(f, dk)
+		md.orig = originalKey(f.orig, dk)
+		md.iface = a.mapValueType(dk)
+		var err error
+		if a.rawMessage && vt == rawMessageType {
+			// Every value is encoded, including nil and zero values that are otherwise skipped.
+			err = a.assignRawMessage(dv, sv, md)
+		} else {
+			err = a.assign(dv, sv, md)
+		}
+		md.iface = nil
+		if err != nil {
 			return err
 		}
 		dm.SetMapIndex(dk, dv)
+		a.progressed(f, total, md)
+	}
+	return nil
+}
+
+// assignSyncMap assigns to a sync.Map from a map.
+// Keys and values are assigned to interfaces and then stored.
+func (a *Assigner) assignSyncMap(dm reflect.Value, sm Source, md *metadata) error {
+	if sk := sm.Kind(); sk != reflect.Map {
+		return newError(dm.Type(), sk)
+	}
+	m := dm.Addr().Interface().(*sync.Map)
+
+	f := md.save()
+	defer md.restore(f)
+
+	for mi := sm.MapRange(); mi.Next(); {
+		if err := a.element(dm.Type(), md); err != nil {
+			return err
+		}
+		dk := a.newValue(interfaceType)
+		sk := mi.Key()
+		md.restore(f)
+		if err := a.assign(dk, sk, md); err != nil {
+			return err
+		}
+		if dk.IsNil() {
+			return newError(dm.Type(), sk.Kind())
+		}
+		if !dk.Elem().Type().Comparable() {
+			return newErrorUnhashableKey(dm.Type(), dk.Elem().Type())
+		}
+		if !a.includeKey(dk) {
+			continue
+		}
+		dv := a.newValue(interfaceType)
+		md.key(f, dk)
+		if err := a.assign(dv, mi.Value(), md); err != nil {
+			return err
+		}
+		m.Store(dk.Interface(), dv.Interface())
 	}
 	return nil
 }
 
+// includeKey reports whether the map entry of the destination key is included by the map filters.
+func (a *Assigner) includeKey(dk reflect.Value) bool {
+	if len(a.mapFilters) == 0 {
+		return true
+	}
+	key := dk.Interface()
+	for _, filter := range a.mapFilters {
+		if !filter(key) {
+			return false
+		}
+	}
+	return true
+}
+
 // assignSlice assigns to a slice.
 func (a *Assigner) assignSlice(ds reflect.Value, ss Source, md *metadata) error {
 	dt := ds.Type()
 	sk := ss.Kind()
+	if sk == reflect.Map && a.mapIndexed {
+		return a.assignIndexed(ds, ss, md)
+	}
+	if sk == reflect.String && a.base64 && isBytes(dt) {
+		return a.assignFromBase64(ds, ss, md)
+	}
+	if sk == reflect.Struct && a.structLists {
+		if sl, ok := a.newStructList(ss); ok {
+			ss, sk = sl, sl.Kind()
+		}
+	}
+	if sk == reflect.String && dt.Elem().Kind() == reflect.Int32 {
+		// Strings are converted to runes by code point, as in Go.
+		return a.assignBasic(ds, ss, md)
+	}
+	if a.scalarList {
+		if sl, ok := newScalarList(ss); ok {
+			ss, sk = sl, sl.Kind()
+		}
+	}
 	if _, ok := listSet[sk]; !ok {
 		return newError(dt, sk)
 	}
+	if a.exceeds(ss.Len(), md) {
+		return newErrorLimit(dt, a.maxElements)
+	}
 	if ds.IsNil() {
 		n := ss.Len()
-		ds.Set(reflect.MakeSlice(dt, n, n))
+		if rs, ok := a.resultSlice(dt, n, md); ok {
+			ds.Set(rs)
+		} else {
+			ds.Set(reflect.MakeSlice(dt, n, n))
+		}
+	} else if a.appendList {
+		off := ds.Len()
+		n := ss.Len()
+		ds.Set(reflect.AppendSlice(ds, reflect.MakeSlice(dt, n, n)))
+		return a.assignList(ds, ss, off, md)
+	} else if a.reuseList {
+		n := ss.Len()
+		if n <= ds.Cap() {
+			ds.Set(ds.Slice(0, n))
+		} else {
+			ds.Set(reflect.AppendSlice(ds, reflect.MakeSlice(dt, n-ds.Len(), n-ds.Len())))
+		}
+		// Zero source values are skipped, so the values of earlier assignments are reset.
+		zeroList(ds)
 	}
-	return a.assignList(ds, ss, md)
+	return a.assignList(ds, ss, 0, md)
 }
 
 // assignArray assigns to an array.
 func (a *Assigner) assignArray(da reflect.Value, sa Source, md *metadata) error {
 	sk := sa.Kind()
+	if sk == reflect.Struct && a.structLists {
+		if sl, ok := a.newStructList(sa); ok {
+			sa, sk = sl, sl.Kind()
+		}
+	}
+	if sk == reflect.Map && a.mapIndexed {
+		return a.assignIndexed(da, sa, md)
+	}
+	if a.scalarList {
+		if sl, ok := newScalarList(sa); ok {
+			sa, sk = sl, sl.Kind()
+		}
+	}
 	if _, ok := listSet[sk]; !ok {
 		return newError(da.Type(), sk)
 	}
-	return a.assignList(da, sa, md)
+	return a.assignList(da, sa, 0, md)
 }
 
-// assignList assigns both slices and arrays to each other.
+// assignIndexed assigns both slices and arrays from a map keyed by index.
+// A nil slice is made with the length of the largest index, bounded by the WithMaxElements option.
+// Indices out of range of the list are skipped, unless strict, see WithStrictIndices.
+func (a *Assigner) assignIndexed(dl reflect.Value, sm Source, md *metadata) error {
+	type entry struct {
+		i  int
+		sv Source
+	}
+	entries := make([]entry, 0, a.sizeHint(sm.Len(), md))
+	n := 0
+	for mi := sm.MapRange(); mi.Next(); {
+		i, err := indexOf(mi.Key())
+		if err != nil {
+			return err
+		}
+		if i >= n {
+			n = i + 1
+		}
+		entries = append(entries, entry{i: i, sv: mi.Value()})
+	}
+	if dl.Kind() == reflect.Slice && dl.IsNil() {
+		// The length is not trusted beyond the limit, e.g. a single key of a large index.
+		if a.exceeds(n, md) {
+			return newErrorLimit(dl.Type(), a.maxElements)
+		}
+		dl.Set(reflect.MakeSlice(dl.Type(), n, n))
+	}
+
+	f := md.save()
+	defer md.restore(f)
+
+	dn := dl.Len()
+	for _, e := range entries {
+		if e.i < 0 || e.i >= dn {
+			if a.strictIndices {
+				return newErrorLimit(dl.Type(), dn)
+			}
+			continue
+		}
+		if err := a.element(dl.Type(), md); err != nil {
+			return err
+		}
+		md.indexAt(f, e.i)
+		if err := a.assign(dl.Index(e.i), e.sv, md); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// assignChan assigns to a channel by sending each element of a slice or array.
+// A nil channel is made with a buffer of the source length.
+// The entries of maps are sent as structs, see entryList.
+// Sources that are not lists are assigned as basic values.
+func (a *Assigner) assignChan(dc reflect.Value, sl Source, md *metadata) error {
+	dt := dc.Type()
+	if sl.Kind() == reflect.Map {
+		sl = newEntryList(sl)
+	}
+	if _, ok := listSet[sl.Kind()]; !ok {
+		return a.assignBasic(dc, sl, md)
+	}
+	if dt.ChanDir()&reflect.SendDir == 0 {
+		return newError(dt, sl.Kind())
+	}
+	n := sl.Len()
+	if a.exceeds(n, md) {
+		return newErrorLimit(dt, a.maxElements)
+	}
+	if dc.IsNil() {
+		dc.Set(reflect.MakeChan(dt, n))
+	}
+
+	f := md.save()
+	defer md.restore(f)
+
+	et := dt.Elem()
+	for i := 0; i < n; i++ {
+		if err := a.element(dt, md); err != nil {
+			return err
+		}
+		de := a.newValue(et)
+		md.indexAt(f, i)
+		if err := a.assign(de, sl.Index(i), md); err != nil {
+			return err
+		}
+		if a.chanBlock {
+			dc.Send(de)
+		} else if !dc.TrySend(de) {
+			return newErrorLimit(dt, dc.Cap())
+		}
+	}
+	return nil
+}
+
+// resultSlice provides a slice of length n for the root destination from the buffer of the WithResultBuffer option.
+// The elements are zeroed and the buffer grows when its capacity is exceeded, the buffer is set to the result.
+// The result is false without the option, below the root destination or for slices of other types.
+func (a *Assigner) resultSlice(dt reflect.Type, n int, md *metadata) (reflect.Value, bool) {
+	buf := a.resultBuf
+	if !buf.IsValid() || !md.root() || buf.Type() != dt {
+		return reflect.Value{}, false
+	}
+	if n > buf.Cap() {
+		buf.Set(reflect.MakeSlice(dt, n, n))
+	}
+	buf.Set(buf.Slice(0, n))
+	zeroList(buf)
+	return buf, true
+}
+
+// zeroList sets the elements of the list to their zero values.
+func zeroList(dl reflect.Value) {
+	for i := 0; i < dl.Len(); i++ {
+		dl.Index(i).SetZero()
+	}
+}
+
+// assignList assigns both slices and arrays to each other
+// starting at the offset of the destination.
 // Varying lengths are permitted.
-func (a *Assigner) assignList(dl reflect.Value, sl Source, md *metadata) error {
+func (a *Assigner) assignList(dl reflect.Value, sl Source, off int, md *metadata) error {
 	n := sl.Len()
-	if dn := dl.Len(); n > dn {
+	if dn := dl.Len() - off; n > dn {
 		n = dn
 	}
+	f := md.save()
+	defer md.restore(f)
+
+	total := sl.Len()
 	for i := 0; i < n; i++ {
-		de := dl.Index(i)
+		if err := a.element(dl.Type(), md); err != nil {
+			return err
+		}
+		de := dl.Index(off + i)
 		se := sl.Index(i)
+		md.indexAt(f, off+i)
+		md.orig = originalIndex(f.orig, off+i)
 		if err := a.assign(de, se, md); err != nil {
 			return err
 		}
+		a.progressed(f, total, md)
 	}
 	return nil
 }
 
+// progressed counts an element of the root source as assigned for the WithProgress option.
+// The progress is reported about every percent of the total and once the total is reached.
+// Elements below the root are not counted.
+func (a *Assigner) progressed(f frame, total int, md *metadata) {
+	if a.progress == nil || f.depth != 0 {
+		return
+	}
+	md.progressed++
+	if step := total / 100; step <= 1 || md.progressed%step == 0 || md.progressed == total {
+		a.progress(md.progressed, total)
+	}
+}
+
+// element counts an element of a map or list to assign,
+// ErrorLimit is returned once the elements exceed the limit of the WithMaxElements option.
+func (a *Assigner) element(dt reflect.Type, md *metadata) error {
+	if a.maxElements <= 0 {
+		return nil
+	}
+	md.elements++
+	if md.elements > a.maxElements {
+		return newErrorLimit(dt, a.maxElements)
+	}
+	return nil
+}
+
+// exceeds reports whether n more elements exceed the limit of the WithMaxElements option.
+// This is checked before lists are allocated by the length of the source.
+func (a *Assigner) exceeds(n int, md *metadata) bool {
+	return a.maxElements > 0 && n > a.maxElements-md.elements
+}
+
+// sizeHint provides the size to preallocate for n elements within the limit of the WithMaxElements option.
+// The length of a map source is not trusted to allocate beyond the limit.
+func (a *Assigner) sizeHint(n int, md *metadata) int {
+	if a.maxElements <= 0 {
+		return n
+	}
+	if rest := a.maxElements - md.elements; n > rest {
+		return rest + 1
+	}
+	return n
+}
+
+// valueOf provides the reflection value of the Source.
+// Go values are used directly which avoids boxing through Source.Interface.
+// Go values from fields that are not exported are read with the WithUnsafeUnexported option.
+func (a *Assigner) valueOf(s Source) reflect.Value {
+	if gs, ok := s.(*goSource); ok {
+		if a.unexported {
+			return readable(gs.val)
+		}
+		if gs.val.CanInterface() {
+			return gs.val
+		}
+	}
+	return reflect.ValueOf(s.Interface())
+}
+
+// canInterface reports whether the Go value of the source can be read.
+// Values obtained from fields that are not exported, e.g. a reflect.Value of a field, are read-only.
+// Read-only values are read with the WithUnsafeUnexported option.
+func (a *Assigner) canInterface(s Source) bool {
+	gs, ok := s.(*goSource)
+	return !ok || a.unexported || !gs.val.IsValid() || gs.val.CanInterface()
+}
+
+// indexOf provides the list index of a map key.
+// Integer keys are used directly and string keys are parsed as integers.
+func indexOf(sk Source) (int, error) {
+	kv := reflect.ValueOf(sk.Interface())
+	switch kv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(kv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return int(kv.Uint()), nil
+	case reflect.String:
+		if i, err := strconv.Atoi(kv.String()); err == nil {
+			return i, nil
+		}
+	}
+	return 0, newError(intType, kv.Kind())
+}
+
 // visit track pointers and checks for cyclical paths.
 // Disable calls to Source.Pointer with the WithoutCycle option.
 func (a *Assigner) visit(v Source, md *metadata) bool {
@@ -232,6 +1414,11 @@ func (a *Assigner) visit(v Source, md *metadata) bool {
 		return false
 	}
 	ptr := v.Pointer()
+	// This case occurs when the Source has no pointer to track.
+	if ptr == 0 {
+		md.cur = 0
+		return false
+	}
 	// This case occurs when the Source has not yet been assigned
 	// because the destination is still being traversed.
 	// The pointer has already been visited since it equals the current pointer.
@@ -246,18 +1433,152 @@ func (a *Assigner) visit(v Source, md *metadata) bool {
 	return ok
 }
 
-// nameOf returns the first name matched by tag key, otherwise the field name.
-// The first and default tag key is `assign`, see WithTags option to include tag keys.
-func (a *Assigner) nameOf(sf reflect.StructField) string {
-	for _, tag := range a.tags {
-		if name := sf.Tag.Get(tag); name != "" {
-			return name
+// recurse tracks the destination type on the current path
+// and reports whether the type is within the limit of nesting in itself.
+func (md *metadata) recurse(dt reflect.Type, limit int) bool {
+	if md.types == nil {
+		md.types = map[reflect.Type]int{}
+	}
+	if md.types[dt] >= limit {
+		return false
+	}
+	md.types[dt]++
+	return true
+}
+
+// unrecurse untracks the destination type from the current path.
+func (md *metadata) unrecurse(dt reflect.Type) {
+	md.types[dt]--
+}
+
+// fieldByName provides the field of the struct Source by name.
+// Getter methods of Go sources are called when the field does not exist with the WithGetters option.
+func (a *Assigner) fieldByName(ss Source, name string) (Source, error) {
+	if gs, ok := ss.(*goSource); ok && len(a.srcTags) > 0 {
+		if sf, ok := a.sourceTagged(gs.val, name); ok {
+			return sf, nil
 		}
 	}
-	return sf.Name
+	sf := ss.FieldByName(name)
+	if !a.getters || sf.Kind() != reflect.Invalid {
+		return sf, nil
+	}
+	if gs, ok := ss.(*goSource); ok {
+		return getterOf(gs.val, name)
+	}
+	return sf, nil
+}
+
+// normalizedFields provides the field names of the struct source by their normalized names.
+// The first field is kept when several fields have the same normalized name.
+// Nil is provided without the WithFieldNameNormalizer option or when the source is not a Fielder.
+func (a *Assigner) normalizedFields(ss Source) map[string]string {
+	fs, ok := ss.(Fielder)
+	if len(a.normalizers) == 0 || !ok {
+		return nil
+	}
+	fields := fs.Fields()
+	names := make(map[string]string, len(fields))
+	for _, name := range fields {
+		norm := a.normalize(name)
+		if _, ok := names[norm]; !ok {
+			names[norm] = name
+		}
+	}
+	return names
+}
+
+// normalize applies the field name normalizers in order.
+func (a *Assigner) normalize(name string) string {
+	for _, norm := range a.normalizers {
+		name = norm(name)
+	}
+	return name
+}
+
+// NormalizeName normalizes the name of a field or key regardless of case and separators,
+// e.g. created_at, created-at, createdAt and CreatedAt are all createdat.
+// The separators are underscores, hyphens, dots and spaces.
+// This is a normalizer for the WithFieldNameNormalizer option.
+func NormalizeName(name string) string {
+	var b strings.Builder
+	b.Grow(len(name))
+	for _, r := range name {
+		switch r {
+		case '_', '-', '.', ' ':
+			continue
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// compositeField provides the value of the composite function of the current destination path,
+// see WithComposite. Nil is provided when there is none.
+func (a *Assigner) compositeField(ss Source, md *metadata) (Source, error) {
+	if len(a.composites) == 0 {
+		return nil, nil
+	}
+	path := md.path()
+	if fn, ok := a.composites[path]; ok {
+		return composite(fn, ss, path)
+	}
+	return nil, nil
+}
+
+// composite provides the value computed from the struct source for the destination path.
+// The error of the function is returned as ErrorSource named by the path.
+func composite(fn func(Source) (interface{}, error), ss Source, path string) (Source, error) {
+	v, err := fn(ss)
+	if err != nil {
+		return nil, newErrorSource(path, err)
+	}
+	return Of(v), nil
+}
+
+// getterOf provides the result of the getter method by name of the struct value.
+// Getters have no parameters and return a value, or a value and an error.
+// The error of a getter is returned as ErrorSource.
+// The Source is invalid when there is no such getter.
+func getterOf(sv reflect.Value, name string) (Source, error) {
+	m := sv.MethodByName(name)
+	if !m.IsValid() && sv.CanAddr() {
+		m = sv.Addr().MethodByName(name)
+	}
+	if !m.IsValid() {
+		return Of(nil), nil
+	}
+	mt := m.Type()
+	if mt.NumIn() != 0 {
+		return Of(nil), nil
+	}
+	switch mt.NumOut() {
+	case 1:
+		return &goSource{val: m.Call(nil)[0]}, nil
+	case 2:
+		if !mt.Out(1).Implements(errorType) {
+			return Of(nil), nil
+		}
+		out := m.Call(nil)
+		if err, _ := out[1].Interface().(error); err != nil {
+			return nil, newErrorSource(name, err)
+		}
+		return &goSource{val: out[0]}, nil
+	}
+	return Of(nil), nil
 }
 
 var (
+	intType   = reflect.TypeOf(0)
+	int64Type = reflect.TypeOf(int64(0))
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+	interfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+	stringMapType = reflect.TypeOf(map[string]interface{}(nil))
+	anyMapType    = reflect.TypeOf(map[interface{}]interface{}(nil))
+	anySliceType  = reflect.TypeOf([]interface{}(nil))
+	syncMapType   = reflect.TypeOf(sync.Map{})
+
 	ptrSet = map[reflect.Kind]struct{}{
 		reflect.Ptr:           {},
 		reflect.Map:           {},
@@ -270,8 +1591,52 @@ var (
 		reflect.Ptr:       {},
 		reflect.Interface: {},
 	}
+	compositeSet = map[reflect.Kind]struct{}{
+		reflect.Struct: {},
+		reflect.Map:    {},
+		reflect.Slice:  {},
+		reflect.Array:  {},
+	}
 	listSet = map[reflect.Kind]struct{}{
 		reflect.Slice: {},
 		reflect.Array: {},
 	}
+	kindTypes = map[reflect.Kind]reflect.Type{
+		reflect.Bool:       reflect.TypeOf(false),
+		reflect.Int:        intType,
+		reflect.Int8:       reflect.TypeOf(int8(0)),
+		reflect.Int16:      reflect.TypeOf(int16(0)),
+		reflect.Int32:      reflect.TypeOf(int32(0)),
+		reflect.Int64:      int64Type,
+		reflect.Uint:       reflect.TypeOf(uint(0)),
+		reflect.Uint8:      reflect.TypeOf(uint8(0)),
+		reflect.Uint16:     reflect.TypeOf(uint16(0)),
+		reflect.Uint32:     reflect.TypeOf(uint32(0)),
+		reflect.Uint64:     reflect.TypeOf(uint64(0)),
+		reflect.Uintptr:    reflect.TypeOf(uintptr(0)),
+		reflect.Float32:    reflect.TypeOf(float32(0)),
+		reflect.Float64:    reflect.TypeOf(float64(0)),
+		reflect.Complex64:  reflect.TypeOf(complex64(0)),
+		reflect.Complex128: reflect.TypeOf(complex128(0)),
+		reflect.String:     reflect.TypeOf(""),
+	}
+	intSet = map[reflect.Kind]struct{}{
+		reflect.Int:   {},
+		reflect.Int8:  {},
+		reflect.Int16: {},
+		reflect.Int32: {},
+		reflect.Int64: {},
+	}
+	uintSet = map[reflect.Kind]struct{}{
+		reflect.Uint:    {},
+		reflect.Uint8:   {},
+		reflect.Uint16:  {},
+		reflect.Uint32:  {},
+		reflect.Uint64:  {},
+		reflect.Uintptr: {},
+	}
+	floatSet = map[reflect.Kind]struct{}{
+		reflect.Float32: {},
+		reflect.Float64: {},
+	}
 )
@@ -1,10 +1,17 @@
 package assign
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -165,6 +172,64 @@ func TestAssignNotExported(t *testing.T) {
 	}
 }
 
+func TestAssignWithUnsafeUnexported(t *testing.T) {
+	t.Parallel()
+	src := Fields{
+		Exported:    "0",
+		notExported: 1,
+	}
+	tests := []struct {
+		name string
+		src  interface{}
+	}{
+		{
+			name: "value",
+			src:  src,
+		},
+		{
+			name: "pointer",
+			src:  &src,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			dst := Fields{}
+			if err := ToFrom(&dst, test.src, WithUnsafeUnexported()); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			// A regular equals expression is used below because
+			// cmp.Diff does not handle fields that are not exported.
+			if src != dst {
+				t.Errorf("expected: %+v but found %+v", src, dst)
+			}
+		})
+	}
+}
+
+func TestAssignWithUnsafeUnexportedNested(t *testing.T) {
+	t.Parallel()
+	src := Internal{
+		small: &Small{Field: "0"},
+		list:  []int{1, 2},
+		dict:  map[string]int{"3": 3},
+	}
+	dst := Internal{}
+	if err := ToFrom(&dst, src, WithUnsafeUnexported()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if diff := cmp.Diff(src, dst, cmp.AllowUnexported(Internal{})); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+	if dst.small == src.small {
+		t.Errorf("expected a copy of: %p but found the same pointer", src.small)
+	}
+}
+
 func TestAssignWithTags(t *testing.T) {
 	t.Parallel()
 
@@ -226,139 +291,2947 @@ func TestAssignWithoutCycle(t *testing.T) {
 	src := panicker{Source: Of(exp)}
 	dst := &Small{}
 
-	// Verify cyclical checks are disabled as Pointer does not panic.
-	if err := ToFrom(dst, src, WithoutCycle()); err != nil {
+	// Verify cyclical checks are disabled as Pointer does not panic.
+	if err := ToFrom(dst, src, WithoutCycle()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	if diff := cmp.Diff(exp, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+}
+*/
+
+func TestAssignErrorType(t *testing.T) {
+	t.Parallel()
+
+	fn := func() {}
+	ch := make(chan struct{})
+	tests := []struct {
+		name string
+		val  interface{}
+	}{
+		{
+			name: "bool",
+			val:  &allValue.Bool,
+		},
+		{
+			name: "string",
+			val:  &allValue.String,
+		},
+		{
+			name: "slice",
+			val:  &allValue.Slice,
+		},
+		{
+			name: "map",
+			val:  &allValue.Map,
+		},
+		{
+			name: "struct",
+			val:  &allValue.Small,
+		},
+		{
+			name: "func",
+			val:  &fn,
+		},
+		{
+			name: "chan",
+			val:  &ch,
+		},
+	}
+
+	expErr := ErrorType{}
+	for _, ti := range tests {
+		for _, tj := range tests {
+			if ti.name == tj.name {
+				continue
+			}
+			ti, tj := ti, tj
+			t.Run(fmt.Sprintf("assign to destination: %s from source: %s", ti.name, tj.name), func(t *testing.T) {
+				t.Parallel()
+				if err := ToFrom(ti.val, tj.val); !errors.As(err, &expErr) {
+					t.Errorf("expected type: %T but found: %T", expErr, err)
+				}
+			})
+			t.Run(fmt.Sprintf("assign to destination: %s from source: %s", tj.name, ti.name), func(t *testing.T) {
+				t.Parallel()
+				if err := ToFrom(tj.val, ti.val); !errors.As(err, &expErr) {
+					t.Errorf("expected type: %T but found: %T", expErr, err)
+				}
+			})
+		}
+	}
+}
+
+func TestAssignNotPointerAndNilPointer(t *testing.T) {
+	t.Parallel()
+	dstAll := reflect.ValueOf(&All{}).Elem()
+	n := dstAll.NumField()
+
+	for i := 0; i < n; i++ {
+		i := i
+		typ := dstAll.Type()
+		name := typ.Field(i).Name
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			df := dstAll.Field(i)
+			dt := df.Type()
+			if dt.Kind() == reflect.Ptr && df.IsNil() {
+				dt = reflect.TypeOf(nil)
+			}
+			exp := newError(dt, reflect.Invalid)
+
+			if err := ToFrom(df, nil); err != exp {
+				t.Errorf("expected: %+v but found %+v", exp, err)
+			}
+		})
+	}
+}
+
+func TestAssignErrorCycle(t *testing.T) {
+	t.Parallel()
+	srcCycle := reflect.ValueOf(newCycle())
+	dstCycle := reflect.ValueOf(&Cycle{}).Elem()
+	expErr := ErrorCycle{}
+
+	n := srcCycle.NumField()
+	for i := 0; i < n; i++ {
+		i := i
+		typ := srcCycle.Type()
+		name := typ.Field(i).Name
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			df := dstCycle.Field(i)
+			sf := srcCycle.Field(i)
+
+			if err := ToFrom(df.Addr(), sf); !errors.As(err, &expErr) {
+				t.Errorf("expected type: %T but found: %T", expErr, err)
+				return
+			}
+		})
+	}
+}
+
+func TestAssignErrorPanic(t *testing.T) {
+	t.Parallel()
+
+	src := panicker{Source: Of(&allValue)}
+	expErr := ErrorPanic{}
+	// Verify cyclical checks are enabled as Pointer panics.
+	if err := ToFrom(&All{}, src); !errors.As(err, &expErr) {
+		t.Errorf("expected type: %T but found: %T", expErr, err)
+		return
+	}
+}
+
+func TestAssignWithMapIndexedLists(t *testing.T) {
+	t.Parallel()
+
+	t.Run("int keys into array", func(t *testing.T) {
+		t.Parallel()
+		dst := [3]string{}
+		src := map[int]string{0: "a", 2: "c"}
+		if err := ToFrom(&dst, src, WithMapIndexedLists()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := [3]string{"a", "", "c"}
+		if diff := cmp.Diff(exp, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+	t.Run("string keys into slice", func(t *testing.T) {
+		t.Parallel()
+		var dst []string
+		src := map[string]string{"1": "b", "3": "d"}
+		if err := ToFrom(&dst, src, WithMapIndexedLists()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := []string{"", "b", "", "d"}
+		if diff := cmp.Diff(exp, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+	t.Run("out of range skipped", func(t *testing.T) {
+		t.Parallel()
+		dst := [2]string{}
+		src := map[int]string{-1: "z", 1: "b", 5: "f"}
+		if err := ToFrom(&dst, src, WithMapIndexedLists()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := [2]string{"", "b"}
+		if diff := cmp.Diff(exp, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+	t.Run("out of range strict", func(t *testing.T) {
+		t.Parallel()
+		dst := [2]string{}
+		src := map[int]string{1: "b", 5: "f"}
+		expErr := ErrorLimit{}
+		if err := ToFrom(&dst, src, WithMapIndexedLists(), WithStrictIndices()); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+			return
+		}
+		if expErr.Limit != 2 {
+			t.Errorf("expected limit: %d but found: %d", 2, expErr.Limit)
+		}
+	})
+	t.Run("large index", func(t *testing.T) {
+		t.Parallel()
+		var dst []string
+		src := map[string]string{"1000000000": "x"}
+		expErr := ErrorLimit{}
+		if err := ToFrom(&dst, src, WithMapIndexedLists(), WithMaxElements(1024)); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+			return
+		}
+		if dst != nil {
+			t.Errorf("expected nil slice but found length: %d", len(dst))
+		}
+	})
+	t.Run("large gap", func(t *testing.T) {
+		t.Parallel()
+		var dst []string
+		src := map[string]string{"0": "a", "5000": "b"}
+		if err := ToFrom(&dst, src, WithMapIndexedLists()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if len(dst) != 5001 || dst[0] != "a" || dst[1] != "" || dst[5000] != "b" {
+			t.Errorf("expected length: %d but found: %d", 5001, len(dst))
+		}
+	})
+	t.Run("invalid key", func(t *testing.T) {
+		t.Parallel()
+		dst := [2]string{}
+		src := map[string]string{"one": "b"}
+		expErr := ErrorType{}
+		if err := ToFrom(&dst, src, WithMapIndexedLists()); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+	t.Run("disabled by default", func(t *testing.T) {
+		t.Parallel()
+		dst := [3]string{}
+		src := map[int]string{0: "a"}
+		expErr := ErrorType{}
+		if err := ToFrom(&dst, src); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+}
+
+func TestAssignWithMapToStruct(t *testing.T) {
+	t.Parallel()
+
+	src := map[interface{}]interface{}{
+		"Field":   "one",
+		"Missing": "two",
+		3:         "three",
+	}
+	dst := Small{}
+	if err := ToFrom(&dst, src, WithMapToStruct()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	exp := Small{Field: "one"}
+	if diff := cmp.Diff(exp, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+}
+
+func TestAssignWithInterfaceType(t *testing.T) {
+	t.Parallel()
+
+	src := map[string]interface{}{
+		"Kind": "event",
+		"Payload": map[string]interface{}{
+			"Name":  "start",
+			"Count": 2,
+		},
+	}
+	tests := []struct {
+		name    string
+		options []Option
+		exp     Envelope
+	}{
+		{
+			name: "pointer",
+			options: []Option{
+				WithMapToStruct(),
+				WithInterfaceType("Payload", reflect.TypeOf(&Event{})),
+			},
+			exp: Envelope{Kind: "event", Payload: &Event{Name: "start", Count: 2}},
+		},
+		{
+			name: "value",
+			options: []Option{
+				WithMapToStruct(),
+				WithInterfaceType("Payload", reflect.TypeOf(Event{})),
+			},
+			exp: Envelope{Kind: "event", Payload: Event{Name: "start", Count: 2}},
+		},
+		{
+			name:    "generic",
+			options: []Option{WithMapToStruct()},
+			exp:     Envelope{Kind: "event", Payload: src["Payload"]},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			dst := Envelope{}
+
+			if err := ToFrom(&dst, src, test.options...); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(test.exp, dst); diff != "" {
+				t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+			}
+		})
+	}
+}
+
+func TestAssignWithInterfaceTypePaths(t *testing.T) {
+	t.Parallel()
+
+	t.Run("root", func(t *testing.T) {
+		t.Parallel()
+		var dst interface{}
+		src := Event{Name: "root"}
+		if err := ToFrom(&dst, src, WithInterfaceType("", reflect.TypeOf(&Event{}))); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := &Event{Name: "root"}
+		if diff := cmp.Diff(exp, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+	t.Run("index", func(t *testing.T) {
+		t.Parallel()
+		dst := make([]interface{}, 2)
+		src := []Event{{Name: "zero"}, {Name: "one"}}
+		if err := ToFrom(&dst, src, WithInterfaceType("[1]", reflect.TypeOf(&Event{}))); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := []interface{}{Event{Name: "zero"}, &Event{Name: "one"}}
+		if diff := cmp.Diff(exp, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+	t.Run("not implemented", func(t *testing.T) {
+		t.Parallel()
+		var dst fmt.Stringer
+		src := Event{Name: "root"}
+		expErr := ErrorType{}
+		if err := ToFrom(&dst, src, WithInterfaceType("", reflect.TypeOf(Event{}))); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+}
+
+func TestAssignWithAppendSlices(t *testing.T) {
+	t.Parallel()
+
+	dst := ListsAppend{}
+	srcs := []ListsAppend{
+		{
+			Slice: []Small{{Field: "0"}, {Field: "1"}},
+			Array: [2]string{"0", "1"},
+		},
+		{
+			Slice: []Small{{Field: "2"}},
+			Array: [2]string{"2"},
+		},
+	}
+	for _, src := range srcs {
+		if err := ToFrom(&dst, src, WithAppendSlices()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+	}
+	exp := ListsAppend{
+		Slice: []Small{{Field: "0"}, {Field: "1"}, {Field: "2"}},
+		Array: [2]string{"2", "1"},
+	}
+	if diff := cmp.Diff(exp, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+}
+
+func TestAssignToStats(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		src    interface{}
+		dst    interface{}
+		opts   []Option
+		exp    Stats
+		expErr bool
+	}{
+		{
+			name: "all value",
+			src:  allValue,
+			dst:  &All{},
+			exp:  Stats{Assigned: 42, Skipped: 27, MaxDepth: 3},
+		},
+		{
+			name: "all pointer",
+			src:  pallValue,
+			dst:  &All{},
+			exp:  Stats{Assigned: 31, Skipped: 34, MaxDepth: 3},
+		},
+		{
+			name: "not exported",
+			src:  Fields{Exported: "0", notExported: 1},
+			dst:  &Fields{},
+			exp:  Stats{Assigned: 1, Skipped: 1, MaxDepth: 1},
+		},
+		{
+			name:   "error",
+			src:    Small{Field: "0"},
+			dst:    &struct{ Field int }{},
+			exp:    Stats{Errored: 1, MaxDepth: 1},
+			expErr: true,
+		},
+		{
+			name: "string truncation",
+			src:  []string{"abc", "a", "abcd"},
+			dst:  new([]string),
+			opts: []Option{WithMaxStringLength(2), WithStringTruncation()},
+			exp:  Stats{Assigned: 3, MaxDepth: 1, Truncated: 2},
+		},
+		{
+			name:   "max errors",
+			src:    []string{"a", "b", "c"},
+			dst:    new([]int),
+			opts:   []Option{WithErrorAggregation(), WithMaxErrors(2)},
+			exp:    Stats{Errored: 3, MaxDepth: 1, Truncated: 1},
+			expErr: true,
+		},
+		{
+			name:   "below max errors",
+			src:    []string{"a", "b", "c"},
+			dst:    new([]int),
+			opts:   []Option{WithErrorAggregation(), WithMaxErrors(4)},
+			exp:    Stats{Errored: 3, MaxDepth: 1},
+			expErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			stats, err := From(test.src, test.opts...).ToStats(test.dst)
+			if (err != nil) != test.expErr {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(test.exp, stats); diff != "" {
+				t.Errorf("(-expected +actual):\n%s\n%+v", diff, stats)
+			}
+		})
+	}
+}
+
+func TestAssignWithRecursionGuard(t *testing.T) {
+	t.Parallel()
+
+	t.Run("recursive source", func(t *testing.T) {
+		t.Parallel()
+		dst := Node{}
+		expErr := ErrorCycle{}
+		if err := ToFrom(&dst, recursive{}, WithRecursionGuard(8)); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+	t.Run("within limit", func(t *testing.T) {
+		t.Parallel()
+		dst := Node{}
+		src := Node{Name: "0", Next: &Node{Name: "1", Next: &Node{Name: "2"}}}
+		if err := ToFrom(&dst, src, WithRecursionGuard(3)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if diff := cmp.Diff(src, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+	t.Run("exceeds limit", func(t *testing.T) {
+		t.Parallel()
+		dst := Node{}
+		src := Node{Name: "0", Next: &Node{Name: "1", Next: &Node{Name: "2"}}}
+		expErr := ErrorCycle{}
+		if err := ToFrom(&dst, src, WithRecursionGuard(2)); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+}
+
+func TestAssignMapIter(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		src  map[string]int
+		exp  map[string]int
+	}{
+		{
+			name: "empty",
+			src:  map[string]int{},
+			exp:  map[string]int{},
+		},
+		{
+			name: "single",
+			src:  map[string]int{"0": 0},
+			exp:  map[string]int{"0": 0},
+		},
+		{
+			name: "multiple",
+			src:  map[string]int{"2": 2, "0": 0, "1": 1},
+			exp:  map[string]int{"0": 0, "1": 1, "2": 2},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var dst map[string]int
+			src := &sortedSource{Source: Of(test.src)}
+
+			if err := ToFrom(&dst, src); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(test.exp, dst); diff != "" {
+				t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+			}
+			if !sort.StringsAreSorted(src.keys) || len(src.keys) != len(test.src) {
+				t.Errorf("expected sorted keys of: %v but found: %v", test.src, src.keys)
+			}
+		})
+	}
+}
+
+func TestAssignWithEnum(t *testing.T) {
+	t.Parallel()
+
+	option := WithEnum(map[reflect.Type]map[string]int{
+		reflect.TypeOf(Status(0)): {
+			"ACTIVE":   int(StatusActive),
+			"INACTIVE": int(StatusInactive),
+		},
+	})
+	t.Run("string to enum", func(t *testing.T) {
+		t.Parallel()
+		dst := Account{}
+		src := map[string]interface{}{"Name": "name", "Status": "INACTIVE"}
+		if err := ToFrom(&dst, src, WithMapToStruct(), option); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := Account{Name: "name", Status: StatusInactive}
+		if diff := cmp.Diff(exp, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+	t.Run("enum to string", func(t *testing.T) {
+		t.Parallel()
+		dst := map[string]string{}
+		src := map[string]Status{"account": StatusActive}
+		if err := ToFrom(&dst, src, option); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := map[string]string{"account": "ACTIVE"}
+		if diff := cmp.Diff(exp, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+	t.Run("unknown name", func(t *testing.T) {
+		t.Parallel()
+		var dst Status
+		expErr := ErrorEnum{}
+		if err := ToFrom(&dst, "DELETED", option); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+	t.Run("unknown value", func(t *testing.T) {
+		t.Parallel()
+		var dst string
+		expErr := ErrorEnum{}
+		if err := ToFrom(&dst, Status(7), option); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+}
+
+func TestAssignPointerDepth(t *testing.T) {
+	t.Parallel()
+
+	small := Small{Field: "0"}
+	psmall := &small
+	ppsmall := &psmall
+	srcs := map[string]interface{}{
+		"Small":   small,
+		"*Small":  psmall,
+		"**Small": ppsmall,
+	}
+	dsts := map[string]func() interface{}{
+		"Small":   func() interface{} { return &Small{} },
+		"*Small":  func() interface{} { return new(*Small) },
+		"**Small": func() interface{} { return new(**Small) },
+	}
+
+	for sn, src := range srcs {
+		for dn, newDst := range dsts {
+			src, newDst := src, newDst
+			t.Run(fmt.Sprintf("assign to destination: %s from source: %s", dn, sn), func(t *testing.T) {
+				t.Parallel()
+				dst := newDst()
+
+				if err := ToFrom(dst, src); err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+
+				dv := reflect.ValueOf(dst)
+				for dv.Kind() == reflect.Ptr {
+					if dv.IsNil() {
+						t.Errorf("expected pointers to be allocated but found: %+v", dst)
+						return
+					}
+					if dv.Interface() == interface{}(psmall) || dv.Interface() == interface{}(ppsmall) {
+						t.Errorf("expected a copy but found the source pointer: %+v", dst)
+						return
+					}
+					dv = dv.Elem()
+				}
+				if diff := cmp.Diff(small, dv.Interface()); diff != "" {
+					t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+				}
+			})
+		}
+	}
+}
+
+func TestAssignWithMapValueType(t *testing.T) {
+	t.Parallel()
+
+	src := map[string]interface{}{
+		"small": map[string]interface{}{"Field": "0"},
+		"event1": map[string]interface{}{
+			"Name":  "start",
+			"Count": 1,
+		},
+		"event2": map[string]interface{}{
+			"Name":  "stop",
+			"Count": 2,
+		},
+		"other": map[string]interface{}{"Field": "1"},
+	}
+	dst := map[string]interface{}{}
+	options := []Option{
+		WithMapToStruct(),
+		WithMapValueType("small", reflect.TypeOf(Small{})),
+		WithMapValueType("event*", reflect.TypeOf(&Event{})),
+	}
+	if err := ToFrom(&dst, src, options...); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	exp := map[string]interface{}{
+		"small":  Small{Field: "0"},
+		"event1": &Event{Name: "start", Count: 1},
+		"event2": &Event{Name: "stop", Count: 2},
+		"other":  map[string]interface{}{"Field": "1"},
+	}
+	if diff := cmp.Diff(exp, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+}
+
+func TestAssignWithStringTransform(t *testing.T) {
+	t.Parallel()
+
+	src := map[string]interface{}{
+		"Name": "  Padded Name\t",
+	}
+	dst := struct{ Name string }{}
+	options := []Option{
+		WithMapToStruct(),
+		WithStringTransform(strings.TrimSpace),
+		WithStringTransform(strings.ToLower),
+	}
+	if err := ToFrom(&dst, src, options...); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if exp := "padded name"; dst.Name != exp {
+		t.Errorf("expected: %q but found %q", exp, dst.Name)
+	}
+
+	named := struct{ Name Name }{}
+	if err := ToFrom(&named, src, options...); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if exp := Name("padded name"); named.Name != exp {
+		t.Errorf("expected: %q but found %q", exp, named.Name)
+	}
+}
+
+func TestAssignWithDottedKeys(t *testing.T) {
+	t.Parallel()
+
+	src := map[string]interface{}{
+		"name":                 "Ada",
+		"address.city":         "NYC",
+		"address.zip":          "10001",
+		"address.geo.lat":      40.7,
+		"labels.env":           "prod",
+		"address.city.ignored": "ignored",
+	}
+	dst := Person{}
+	if err := ToFrom(&dst, src, WithDottedKeys()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	exp := Person{
+		Name: "Ada",
+		Address: Address{
+			City: "NYC",
+			Zip:  "10001",
+			Geo:  &Geo{Lat: 40.7},
+		},
+		Labels: map[string]string{"env": "prod"},
+	}
+	if diff := cmp.Diff(exp, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+}
+
+func TestAssignWithDottedKeysPrecedence(t *testing.T) {
+	t.Parallel()
+
+	src := map[string]interface{}{
+		"address":      map[string]interface{}{"city": "LA"},
+		"address.city": "NYC",
+	}
+	dst := Person{}
+	if err := ToFrom(&dst, src, WithDottedKeys()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	exp := Person{Address: Address{City: "LA"}}
+	if diff := cmp.Diff(exp, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+}
+
+func TestAssignWithDiscriminator(t *testing.T) {
+	t.Parallel()
+
+	option := WithDiscriminator("type", map[string]reflect.Type{
+		"circle": reflect.TypeOf(&Circle{}),
+		"square": reflect.TypeOf(Square{}),
+	})
+	t.Run("registered", func(t *testing.T) {
+		t.Parallel()
+		src := []interface{}{
+			map[string]interface{}{"type": "circle", "Radius": 1.5},
+			map[string]interface{}{"type": "square", "Side": 2.0},
+		}
+		var dst []Shape
+		if err := ToFrom(&dst, src, option); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := []Shape{&Circle{Type: "circle", Radius: 1.5}, Square{Type: "square", Side: 2}}
+		if diff := cmp.Diff(exp, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+	t.Run("interfaces", func(t *testing.T) {
+		t.Parallel()
+		src := []map[string]interface{}{
+			{"type": "square", "Side": 2.0},
+			{"type": "circle", "Radius": 1.5},
+			{"type": "square", "Side": 3.0},
+		}
+		var dst []interface{}
+		if err := ToFrom(&dst, src, option); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := []interface{}{
+			Square{Type: "square", Side: 2},
+			&Circle{Type: "circle", Radius: 1.5},
+			Square{Type: "square", Side: 3},
+		}
+		if diff := cmp.Diff(exp, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+	t.Run("not registered", func(t *testing.T) {
+		t.Parallel()
+		src := []interface{}{
+			map[string]interface{}{"type": "triangle", "Side": 3.0},
+			map[string]interface{}{"Side": 4.0},
+		}
+		var dst []interface{}
+		if err := ToFrom(&dst, src, option); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if diff := cmp.Diff(src, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+}
+
+func TestAssignOpaque(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		src  All
+	}{
+		{
+			name: "all value",
+			src:  allValue,
+		},
+		{
+			name: "all pointer",
+			src:  pallValue,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			exp := All{}
+			if err := ToFrom(&exp, test.src); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			dst := All{}
+			if err := ToFrom(&dst, opaque{Source: Of(test.src)}); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(exp, dst); diff != "" {
+				t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+			}
+		})
+	}
+}
+
+func BenchmarkAssign(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		dst := All{}
+		if err := ToFrom(&dst, allValue); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func BenchmarkAssignOpaque(b *testing.B) {
+	b.ReportAllocs()
+	src := opaque{Source: Of(allValue)}
+	for i := 0; i < b.N; i++ {
+		dst := All{}
+		if err := ToFrom(&dst, src); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func BenchmarkAssignReuseSliceBuffer(b *testing.B) {
+	b.ReportAllocs()
+	src := make([]Small, 64)
+	for i := range src {
+		src[i] = Small{Field: strconv.Itoa(i)}
+	}
+	dst := []Small{}
+	assigner := From(src, WithReuseSliceBuffer())
+	for i := 0; i < b.N; i++ {
+		if err := assigner.To(&dst); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func BenchmarkAssignWithResultBuffer(b *testing.B) {
+	b.ReportAllocs()
+	src := make([]Small, 64)
+	for i := range src {
+		src[i] = Small{Field: strconv.Itoa(i)}
+	}
+	var buf []Small
+	assigner := From(src, WithResultBuffer(&buf))
+	for i := 0; i < b.N; i++ {
+		var dst []Small
+		if err := assigner.To(&dst); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestAssignWithResultBuffer(t *testing.T) {
+	t.Parallel()
+
+	var buf []Small
+	srcs := [][]Small{
+		{{Field: "0"}, {Field: "1"}, {Field: "2"}},
+		{{Field: "3"}, {}},
+		{{Field: "4"}, {Field: "5"}, {Field: "6"}, {Field: "7"}},
+		{},
+	}
+	for i, src := range srcs {
+		cp := cap(buf)
+		var dst []Small
+		if err := ToFrom(&dst, src, WithResultBuffer(&buf)); err != nil {
+			t.Errorf("unexpected error: %v of decode: %d", err, i)
+			return
+		}
+		if diff := cmp.Diff(src, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+		if len(src) <= cp && len(src) > 0 && &dst[0] != &buf[:1][0] {
+			t.Errorf("expected the buffer of capacity: %d to be reused for length: %d", cp, len(src))
+		}
+	}
+
+	t.Run("nested", func(t *testing.T) {
+		t.Parallel()
+		var buf []string
+		type Strings struct{ Values []string }
+		src := Strings{Values: []string{"0", "1"}}
+		dst := Strings{}
+		if err := ToFrom(&dst, src, WithResultBuffer(&buf)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if buf != nil {
+			t.Errorf("expected nil buffer but found: %+v", buf)
+		}
+	})
+}
+
+func TestAssignWithPointerSharing(t *testing.T) {
+	t.Parallel()
+
+	src := All{
+		PSmall:  &Small{Field: "0"},
+		PPSmall: new(*Small),
+		SliceP:  []*Small{{Field: "1"}},
+	}
+	*src.PPSmall = &Small{Field: "2"}
+	dst := All{}
+	if err := ToFrom(&dst, src, WithPointerSharing()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if dst.PSmall != src.PSmall {
+		t.Errorf("expected pointer: %p but found: %p", src.PSmall, dst.PSmall)
+	}
+	if dst.PPSmall != src.PPSmall {
+		t.Errorf("expected pointer: %p but found: %p", src.PPSmall, dst.PPSmall)
+	}
+	if dst.SliceP[0] != src.SliceP[0] {
+		t.Errorf("expected pointer: %p but found: %p", src.SliceP[0], dst.SliceP[0])
+	}
+
+	copied := All{}
+	if err := ToFrom(&copied, src); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if copied.PSmall == src.PSmall {
+		t.Errorf("expected a copy of pointer: %p", src.PSmall)
+	}
+}
+
+func TestAssignWithPointerSharingTypes(t *testing.T) {
+	t.Parallel()
+
+	i := 1
+	dst := struct{ P *int64 }{}
+	src := struct{ P *int }{P: &i}
+	if err := ToFrom(&dst, src, WithPointerSharing()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if dst.P == nil || *dst.P != 1 {
+		t.Errorf("expected: %d but found: %v", i, dst.P)
+	}
+}
+
+func TestAssignWithGetters(t *testing.T) {
+	t.Parallel()
+
+	t.Run("values", func(t *testing.T) {
+		t.Parallel()
+		src := &User{First: "Ada", Last: "Lovelace", birth: 1815}
+		dst := UserView{}
+		if err := ToFrom(&dst, src, WithGetters()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := UserView{FullName: "Ada Lovelace", Age: 37, Initials: "AL"}
+		if diff := cmp.Diff(exp, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		src := User{First: "Ada", Last: "Lovelace"}
+		dst := UserView{}
+		expErr := ErrorSource{}
+		err := ToFrom(&dst, src, WithGetters())
+		if !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+			return
+		}
+		if !errors.Is(err, errNoBirth) || expErr.Name != "Age" {
+			t.Errorf("expected source: Age with error: %v but found: %v", errNoBirth, err)
+		}
+	})
+	t.Run("without option", func(t *testing.T) {
+		t.Parallel()
+		src := User{First: "Ada", Last: "Lovelace"}
+		dst := UserView{}
+		if err := ToFrom(&dst, src); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if diff := cmp.Diff(UserView{}, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+}
+
+func TestAssignSyncMap(t *testing.T) {
+	t.Parallel()
+
+	src := map[string]int{"0": 0, "1": 1, "2": 2}
+	dst := &sync.Map{}
+	if err := ToFrom(&dst, src); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	act := map[string]interface{}{}
+	dst.Range(func(key, value interface{}) bool {
+		act[key.(string)] = value
+		return true
+	})
+	// The zero value is skipped and stored as a nil interface.
+	exp := map[string]interface{}{"0": nil, "1": 1, "2": 2}
+	if diff := cmp.Diff(exp, act); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, act)
+	}
+}
+
+func TestAssignSyncMapField(t *testing.T) {
+	t.Parallel()
+
+	src := map[string]interface{}{"Cache": map[string]string{"key": "value"}}
+	dst := struct{ Cache *sync.Map }{}
+	if err := ToFrom(&dst, src, WithMapToStruct()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if v, ok := dst.Cache.Load("key"); !ok || v != "value" {
+		t.Errorf("expected: %v but found: %v", "value", v)
+	}
+
+	expErr := ErrorType{}
+	if err := ToFrom(&dst, map[string]interface{}{"Cache": []int{1}}, WithMapToStruct()); !errors.As(err, &expErr) {
+		t.Errorf("expected type: %T but found: %T", expErr, err)
+	}
+}
+
+func TestAssignWithMapFilter(t *testing.T) {
+	t.Parallel()
+
+	src := map[string]Small{
+		"public":   {Field: "0"},
+		"_private": {Field: "1"},
+		"_secret":  {Field: "2"},
+		"other":    {Field: "3"},
+	}
+	dst := map[string]Small{"_kept": {Field: "4"}}
+	options := []Option{
+		WithMapFilter(func(key interface{}) bool {
+			return !strings.HasPrefix(key.(string), "_")
+		}),
+		WithMapFilter(func(key interface{}) bool {
+			return key != "other"
+		}),
+	}
+	if err := ToFrom(&dst, src, options...); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	exp := map[string]Small{
+		"public": {Field: "0"},
+		"_kept":  {Field: "4"},
+	}
+	if diff := cmp.Diff(exp, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+}
+
+func TestAssignWithChannelFill(t *testing.T) {
+	t.Parallel()
+
+	t.Run("buffered", func(t *testing.T) {
+		t.Parallel()
+		dst := make(chan Small, 3)
+		src := []map[string]interface{}{{"Field": "0"}, {"Field": "1"}, {"Field": "2"}}
+		if err := ToFrom(&dst, src, WithChannelFill(false), WithMapToStruct()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		close(dst)
+		act := []Small{}
+		for s := range dst {
+			act = append(act, s)
+		}
+		exp := []Small{{Field: "0"}, {Field: "1"}, {Field: "2"}}
+		if diff := cmp.Diff(exp, act); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, act)
+		}
+	})
+	t.Run("map", func(t *testing.T) {
+		t.Parallel()
+		type KV struct {
+			Key   string
+			Value Small
+		}
+		dst := make(chan KV, 3)
+		src := map[string]Small{"a": {Field: "0"}, "b": {Field: "1"}, "c": {}}
+		if err := ToFrom(&dst, src, WithChannelFill(false)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		close(dst)
+		act := map[string]Small{}
+		for kv := range dst {
+			act[kv.Key] = kv.Value
+		}
+		if diff := cmp.Diff(src, act); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, act)
+		}
+	})
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+		var dst chan int
+		src := [2]int{1, 2}
+		if err := ToFrom(&dst, src, WithChannelFill(false)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if n := len(dst); n != 2 || <-dst != 1 || <-dst != 2 {
+			t.Errorf("expected elements: %v but found: %d", src, n)
+		}
+	})
+	t.Run("blocking", func(t *testing.T) {
+		t.Parallel()
+		dst := make(chan int)
+		act := make(chan []int)
+		go func() {
+			elems := []int{}
+			for e := range dst {
+				elems = append(elems, e)
+			}
+			act <- elems
+		}()
+		src := []int{1, 2, 3}
+		if err := ToFrom(&dst, src, WithChannelFill(true)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		close(dst)
+		if diff := cmp.Diff(src, <-act); diff != "" {
+			t.Errorf("(-expected +actual):\n%s", diff)
+		}
+	})
+	t.Run("full", func(t *testing.T) {
+		t.Parallel()
+		dst := make(chan int, 1)
+		expErr := ErrorLimit{}
+		if err := ToFrom(&dst, []int{1, 2}, WithChannelFill(false)); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+}
+
+func TestAssignWithReuseSliceBuffer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		dst  []int
+		src  []int
+		exp  []int
+		cap  int
+	}{
+		{name: "shrink", dst: make([]int, 4), src: []int{1, 2}, exp: []int{1, 2}, cap: 4},
+		{name: "within capacity", dst: make([]int, 1, 4), src: []int{1, 2, 3}, exp: []int{1, 2, 3}, cap: 4},
+		{name: "grow", dst: []int{0}, src: []int{1, 2, 3}, exp: []int{1, 2, 3}},
+		{name: "nil", src: []int{1, 2}, exp: []int{1, 2}, cap: 2},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			dst := test.dst
+			if err := ToFrom(&dst, test.src, WithReuseSliceBuffer()); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if diff := cmp.Diff(test.exp, dst); diff != "" {
+				t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+			}
+			if test.cap > 0 {
+				if cap(dst) != test.cap {
+					t.Errorf("expected capacity: %d but found: %d", test.cap, cap(dst))
+				}
+				if test.dst != nil && &dst[0] != &test.dst[0] {
+					t.Errorf("expected backing array to be reused")
+				}
+			}
+		})
+	}
+}
+
+func TestAssignWithReuseSliceBufferZero(t *testing.T) {
+	t.Parallel()
+
+	type Point struct {
+		X, Y int
+	}
+	buf := []Point{}
+	reuse := WithReuseSliceBuffer()
+	if err := ToFrom(&buf, []Point{{X: 1, Y: 2}, {X: 3, Y: 4}}, reuse); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if err := ToFrom(&buf, []Point{{Y: 9}}, reuse); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if diff := cmp.Diff([]Point{{Y: 9}}, buf); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, buf)
+	}
+	buf = buf[:0]
+	if err := ToFrom(&buf, []Point{{}, {Y: 7}}, reuse); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if diff := cmp.Diff([]Point{{}, {Y: 7}}, buf); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, buf)
+	}
+}
+
+func TestAssignWithErrorAggregation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("join", func(t *testing.T) {
+		t.Parallel()
+		src := map[string]interface{}{
+			"Field": "ok",
+			"Int":   []int{1},
+			"Slice": "string",
+		}
+		dst := struct {
+			Field string
+			Int   int
+			Slice []int
+		}{}
+		err := ToFrom(&dst, src, WithMapToStruct(), WithErrorAggregation())
+		expErr := ErrorType{}
+		if !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+			return
+		}
+		if errs := err.(interface{ Unwrap() []error }).Unwrap(); len(errs) != 2 {
+			t.Errorf("expected errors: %d but found: %d", 2, len(errs))
+		}
+		for _, msg := range []string{
+			newError(reflect.TypeOf(0), reflect.Slice).Error(),
+			newError(reflect.TypeOf([]int{}), reflect.String).Error(),
+		} {
+			if !strings.Contains(err.Error(), msg) {
+				t.Errorf("expected message: %q in: %q", msg, err.Error())
+			}
+		}
+		if dst.Field != "ok" {
+			t.Errorf("expected field: %q but found: %q", "ok", dst.Field)
+		}
+	})
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+		src := map[string]interface{}{
+			"Field": "ok",
+			"Int":   []int{1},
+			"Slice": "string",
+		}
+		dst := struct {
+			Field string
+			Int   int
+			Slice []int
+		}{}
+		b, err := json.Marshal(ToFrom(&dst, src, WithMapToStruct(), WithErrorAggregation()))
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		var actual []map[string]string
+		if err := json.Unmarshal(b, &actual); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		expected := []map[string]string{
+			{"path": "Int", "type": "ErrorType", "message": newError(reflect.TypeOf(0), reflect.Slice).Error()},
+			{"path": "Slice", "type": "ErrorType", "message": newError(reflect.TypeOf([]int{}), reflect.String).Error()},
+		}
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%s", diff, b)
+		}
+	})
+	t.Run("paths", func(t *testing.T) {
+		t.Parallel()
+		type Item struct {
+			Counts map[string]int
+		}
+		src := map[string]interface{}{
+			"Items": []interface{}{
+				map[string]interface{}{"Counts": map[string]interface{}{"a": 1}},
+				map[string]interface{}{"Counts": map[string]interface{}{"b": "two"}},
+			},
+		}
+		dst := struct{ Items []Item }{}
+		err := ToFrom(&dst, src, WithMapToStruct(), WithErrorAggregation())
+		multi := ErrorMultiple{}
+		if !errors.As(err, &multi) {
+			t.Errorf("expected type: %T but found: %T", multi, err)
+			return
+		}
+		if diff := cmp.Diff([]string{"Items[1].Counts[b]"}, multi.Paths); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, multi.Paths)
+		}
+	})
+	t.Run("cycle", func(t *testing.T) {
+		t.Parallel()
+		dst := Cycle{}
+		expErr := ErrorCycle{}
+		if err := ToFrom(&dst, newCycle(), WithErrorAggregation()); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+	t.Run("panic", func(t *testing.T) {
+		t.Parallel()
+		src := panicker{Source: Of(&allValue)}
+		expErr := ErrorPanic{}
+		if err := ToFrom(&All{}, src, WithErrorAggregation()); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+	t.Run("none", func(t *testing.T) {
+		t.Parallel()
+		dst := All{}
+		if err := ToFrom(&dst, allValue, WithErrorAggregation()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestAssignWithMaxErrors(t *testing.T) {
+	t.Parallel()
+
+	src := []interface{}{"0", 1, "2", 3, "4", 5, "6"}
+	tests := []struct {
+		name      string
+		max       int
+		errs      int
+		truncated bool
+	}{
+		{name: "truncated", max: 2, errs: 2, truncated: true},
+		{name: "exact", max: 4, errs: 4},
+		{name: "below", max: 5, errs: 4},
+		{name: "disabled", max: 0, errs: 4},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var dst []int
+			err := ToFrom(&dst, src, WithErrorAggregation(), WithMaxErrors(test.max))
+			multi := ErrorMultiple{}
+			if !errors.As(err, &multi) {
+				t.Errorf("expected type: %T but found: %T", multi, err)
+				return
+			}
+			if multi.Truncated != test.truncated {
+				t.Errorf("expected truncated: %t but found: %t", test.truncated, multi.Truncated)
+			}
+			if len(multi.Errors) != test.errs || len(multi.Paths) != test.errs {
+				t.Errorf("expected errors: %d but found: %d", test.errs, len(multi.Errors))
+			}
+			for _, err := range multi.Errors {
+				if !errors.As(err, &ErrorType{}) {
+					t.Errorf("expected type: %T but found: %T", ErrorType{}, err)
+				}
+			}
+		})
+	}
+	t.Run("other limit", func(t *testing.T) {
+		t.Parallel()
+		var dst []string
+		err := ToFrom(&dst, []string{"abc"}, WithErrorAggregation(), WithMaxErrors(2), WithMaxStringLength(2))
+		multi := ErrorMultiple{}
+		if !errors.As(err, &multi) {
+			t.Errorf("expected type: %T but found: %T", multi, err)
+			return
+		}
+		if multi.Truncated || len(multi.Errors) != 1 {
+			t.Errorf("expected errors: %d untruncated but found: %d truncated: %t", 1, len(multi.Errors), multi.Truncated)
+		}
+	})
+}
+
+func TestAssignWithFailFast(t *testing.T) {
+	t.Parallel()
+
+	src := []interface{}{"0", 1, "2", 3, "4"}
+	tests := []struct {
+		name    string
+		options []Option
+		errs    int
+	}{
+		{name: "fail", options: []Option{WithFailFast(true)}, errs: 1},
+		{name: "collect", options: []Option{WithFailFast(false)}, errs: 3},
+		{name: "bounded", options: []Option{WithFailFast(false), WithMaxErrors(2)}, errs: 2},
+		{name: "override", options: []Option{WithErrorAggregation(), WithFailFast(true)}, errs: 1},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var dst []int
+			err := ToFrom(&dst, src, test.options...)
+			n := 0
+			errs := []error{err}
+			if multi, ok := err.(ErrorMultiple); ok {
+				errs = multi.Errors
+			}
+			for _, err := range errs {
+				if errors.As(err, &ErrorType{}) {
+					n++
+				}
+			}
+			if n != test.errs {
+				t.Errorf("expected errors: %d but found: %d", test.errs, n)
+			}
+		})
+	}
+}
+
+func TestAssignNamedCollections(t *testing.T) {
+	t.Parallel()
+
+	t.Run("map", func(t *testing.T) {
+		t.Parallel()
+		src := map[string][]string{"Accept": {"text/plain", "text/html"}}
+		var dst Headers
+		if err := ToFrom(&dst, src); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if diff := cmp.Diff(Headers(src), dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+	t.Run("slice", func(t *testing.T) {
+		t.Parallel()
+		src := []int{1, 2, 3}
+		var dst IDs
+		if err := ToFrom(&dst, src); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if diff := cmp.Diff(IDs(src), dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+	t.Run("underlying", func(t *testing.T) {
+		t.Parallel()
+		src := Headers{"Accept": {"text/plain"}}
+		dst := map[string][]string{}
+		if err := ToFrom(&dst, src); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if diff := cmp.Diff(map[string][]string(src), dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+	t.Run("elements", func(t *testing.T) {
+		t.Parallel()
+		src := map[string]IDs{"a": {1, 2}}
+		dst := map[string][]int64{}
+		if err := ToFrom(&dst, src); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if diff := cmp.Diff(map[string][]int64{"a": {1, 2}}, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+}
+
+func TestAssignWithFieldNameNormalizer(t *testing.T) {
+	t.Parallel()
+
+	normalize := func(name string) string {
+		return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+	}
+	tests := []struct {
+		name string
+		src  interface{}
+	}{
+		{name: "snake", src: map[string]interface{}{"user_id": 1}},
+		{name: "exact", src: map[string]interface{}{"UserID": 1}},
+		{name: "camel", src: map[string]interface{}{"userId": 1}},
+		{name: "struct", src: struct{ User_ID int }{User_ID: 1}},
+		{name: "preferred", src: map[string]interface{}{"user_id": 2, "UserID": 1}},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			dst := struct{ UserID int }{}
+			if err := ToFrom(&dst, test.src, WithMapToStruct(), WithFieldNameNormalizer(normalize)); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if dst.UserID != 1 {
+				t.Errorf("expected field: %d but found: %d", 1, dst.UserID)
+			}
+		})
+	}
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+		dst := struct{ UserID int }{}
+		if err := ToFrom(&dst, map[string]interface{}{"user_id": 1}, WithMapToStruct()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if dst.UserID != 0 {
+			t.Errorf("expected field: %d but found: %d", 0, dst.UserID)
+		}
+	})
+}
+
+func TestAssignWithNormalizeName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  interface{}
+	}{
+		{
+			name: "snake",
+			src: map[string]interface{}{
+				"user_id":    1,
+				"created_at": "2021-01-02T03:04:05Z",
+				"home_address": map[string]interface{}{
+					"street_name": "Main",
+				},
+			},
+		},
+		{
+			name: "kebab",
+			src: map[string]interface{}{
+				"user-id":    1,
+				"created-at": "2021-01-02T03:04:05Z",
+				"home-address": map[string]interface{}{
+					"street-name": "Main",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			act := Normalized{}
+			if err := ToFrom(&act, test.src, WithMapToStruct(), WithFieldNameNormalizer(NormalizeName)); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			exp := Normalized{
+				UserID:      1,
+				CreatedAt:   time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC),
+				HomeAddress: &StreetAddress{StreetName: "Main"},
+			}
+			if diff := cmp.Diff(exp, act); diff != "" {
+				t.Errorf("(-expected +actual):\n%s\n%+v", diff, act)
+			}
+		})
+	}
+}
+
+func TestAssignWithOverflowCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		dst  interface{}
+		src  interface{}
+		ok   bool
+	}{
+		{name: "negative int to uint", dst: new(uint), src: -1},
+		{name: "negative int64 to uint64", dst: new(uint64), src: int64(-1)},
+		{name: "large uint to int", dst: new(int), src: uint(math.MaxUint64)},
+		{name: "large uint64 to int64", dst: new(int64), src: uint64(math.MaxInt64 + 1)},
+		{name: "wide int to int8", dst: new(int8), src: 128},
+		{name: "wide uint to uint8", dst: new(uint8), src: uint(256)},
+		{name: "wide float64 to float32", dst: new(float32), src: math.MaxFloat64},
+		{name: "int to uint", dst: new(uint), src: 1, ok: true},
+		{name: "max uint to int", dst: new(int64), src: uint64(math.MaxInt64), ok: true},
+		{name: "int to int8", dst: new(int8), src: -128, ok: true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := ToFrom(test.dst, test.src, WithOverflowCheck())
+			if test.ok {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			expErr := ErrorOverflow{}
+			if !errors.As(err, &expErr) {
+				t.Errorf("expected type: %T but found: %T", expErr, err)
+			}
+		})
+	}
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+		dst := uint(0)
+		if err := ToFrom(&dst, -1); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if dst != math.MaxUint {
+			t.Errorf("expected value: %d but found: %d", uint(math.MaxUint), dst)
+		}
+	})
+}
+
+func TestAssignWithInferInterfaceType(t *testing.T) {
+	t.Parallel()
+
+	type Named string
+	tests := []struct {
+		name string
+		src  interface{}
+		exp  interface{}
+	}{
+		{name: "struct", src: Small{Field: "0"}, exp: Small{Field: "0"}},
+		{name: "map", src: map[string][]int{"a": {1}}, exp: map[string]interface{}{"a": []interface{}{1}}},
+		{name: "map of int keys", src: map[int]string{1: "a"}, exp: map[interface{}]interface{}{1: "a"}},
+		{name: "slice", src: []int8{1, 2}, exp: []interface{}{int8(1), int8(2)}},
+		{name: "array", src: [1]string{"a"}, exp: []interface{}{"a"}},
+		{name: "scalar", src: Named("a"), exp: "a"},
+		{name: "pointer", src: &Small{Field: "0"}, exp: Small{Field: "0"}},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			src := struct{ Value interface{} }{Value: test.src}
+			dst := struct{ Value interface{} }{}
+			if err := ToFrom(&dst, src, WithInferInterfaceType()); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if diff := cmp.Diff(test.exp, dst.Value); diff != "" {
+				t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst.Value)
+			}
+		})
+	}
+	t.Run("shared", func(t *testing.T) {
+		t.Parallel()
+		src := struct{ Value interface{} }{Value: map[string]int{"a": 1}}
+		dst := struct{ Value interface{} }{}
+		if err := ToFrom(&dst, src, WithInferInterfaceType()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		src.Value.(map[string]int)["a"] = 2
+		if diff := cmp.Diff(map[string]interface{}{"a": 1}, dst.Value); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst.Value)
+		}
+	})
+}
+
+func TestAssignWithProgress(t *testing.T) {
+	t.Parallel()
+
+	t.Run("slice", func(t *testing.T) {
+		t.Parallel()
+		src := make([]Small, 1000)
+		for i := range src {
+			src[i] = Small{Field: strconv.Itoa(i)}
+		}
+		var dst []Small
+		var reports []int
+		progress := func(assigned, total int) {
+			if total != len(src) {
+				t.Errorf("expected total: %d but found: %d", len(src), total)
+			}
+			reports = append(reports, assigned)
+		}
+		if err := ToFrom(&dst, src, WithProgress(progress)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if n := len(reports); n != 100 {
+			t.Errorf("expected reports: %d but found: %d", 100, n)
+		}
+		for i := 1; i < len(reports); i++ {
+			if reports[i] <= reports[i-1] {
+				t.Errorf("expected increasing reports but found: %d after %d", reports[i], reports[i-1])
+			}
+		}
+		if last := reports[len(reports)-1]; last != len(src) {
+			t.Errorf("expected last report: %d but found: %d", len(src), last)
+		}
+	})
+	t.Run("map", func(t *testing.T) {
+		t.Parallel()
+		src := map[string][]int{"a": {1, 2}, "b": {3}, "c": nil}
+		dst := map[string][]int{}
+		var reports []int
+		progress := func(assigned, total int) {
+			reports = append(reports, assigned)
+		}
+		if err := ToFrom(&dst, src, WithProgress(progress)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if diff := cmp.Diff([]int{1, 2, 3}, reports); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, reports)
+		}
+	})
+}
+
+func TestAssignPointerWidths(t *testing.T) {
+	t.Parallel()
+
+	i, i8, u16, u64, f32 := 1, int8(2), uint16(3), uint64(4), float32(5)
+	tests := []struct {
+		name string
+		dst  interface{}
+		src  interface{}
+		exp  interface{}
+	}{
+		{name: "*int to *int64", dst: new(*int64), src: &i, exp: int64(1)},
+		{name: "*int to *int8", dst: new(*int8), src: &i, exp: int8(1)},
+		{name: "*int8 to *int32", dst: new(*int32), src: &i8, exp: int32(2)},
+		{name: "*int8 to *uint", dst: new(*uint), src: &i8, exp: uint(2)},
+		{name: "*uint16 to *uint64", dst: new(*uint64), src: &u16, exp: uint64(3)},
+		{name: "*uint64 to *int", dst: new(*int), src: &u64, exp: 4},
+		{name: "*uint64 to *float32", dst: new(*float32), src: &u64, exp: float32(4)},
+		{name: "*float32 to *float64", dst: new(*float64), src: &f32, exp: float64(5)},
+		{name: "*float32 to *int16", dst: new(*int16), src: &f32, exp: int16(5)},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			if err := ToFrom(test.dst, test.src); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			dp := reflect.ValueOf(test.dst).Elem()
+			if dp.IsNil() {
+				t.Errorf("expected pointer to be allocated")
+				return
+			}
+			if diff := cmp.Diff(test.exp, dp.Elem().Interface()); diff != "" {
+				t.Errorf("(-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAssignPointerElements(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		dst  interface{}
+		src  interface{}
+		exp  interface{}
+	}{
+		{
+			name: "map of values to map of pointers",
+			dst:  new(map[string]*Small),
+			src:  map[string]Small{"a": {Field: "0"}, "b": {}},
+			exp:  map[string]*Small{"a": {Field: "0"}, "b": nil},
+		},
+		{
+			name: "map of pointers to map of values",
+			dst:  new(map[string]Small),
+			src:  map[string]*Small{"a": {Field: "0"}, "b": nil},
+			exp:  map[string]Small{"a": {Field: "0"}, "b": {}},
+		},
+		{
+			name: "map of pointers to map of pointers",
+			dst:  new(map[string]*Small),
+			src:  map[string]*Small{"a": {Field: "0"}, "b": nil},
+			exp:  map[string]*Small{"a": {Field: "0"}, "b": nil},
+		},
+		{
+			name: "slice of values to slice of pointers",
+			dst:  new([]*Small),
+			src:  []Small{{Field: "0"}, {}},
+			exp:  []*Small{{Field: "0"}, nil},
+		},
+		{
+			name: "slice of pointers to slice of values",
+			dst:  new([]Small),
+			src:  []*Small{{Field: "0"}, nil},
+			exp:  []Small{{Field: "0"}, {}},
+		},
+		{
+			name: "slice of pointers to slice of pointers",
+			dst:  new([]*Small),
+			src:  []*Small{nil, {Field: "0"}},
+			exp:  []*Small{nil, {Field: "0"}},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			if err := ToFrom(test.dst, test.src); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			act := reflect.ValueOf(test.dst).Elem().Interface()
+			if diff := cmp.Diff(test.exp, act); diff != "" {
+				t.Errorf("(-expected +actual):\n%s\n%+v", diff, act)
+			}
+		})
+	}
+}
+
+func TestAssignWithValueTransform(t *testing.T) {
+	t.Parallel()
+
+	clamp := func(_ string, dst reflect.Type, src interface{}) (interface{}, bool) {
+		if n, ok := src.(int); ok && dst.Kind() == reflect.Int && n > 100 {
+			return 100, true
+		}
+		return nil, false
+	}
+	redact := func(path string, _ reflect.Type, _ interface{}) (interface{}, bool) {
+		if path == "Secrets[1]" {
+			return "***", true
+		}
+		return nil, false
+	}
+	src := struct {
+		Levels  []int
+		Secrets []string
+	}{
+		Levels:  []int{1, 101, 1000},
+		Secrets: []string{"public", "password"},
+	}
+	dst := struct {
+		Levels  []int
+		Secrets []string
+	}{}
+	if err := ToFrom(&dst, src, WithValueTransform(clamp), WithValueTransform(redact)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if diff := cmp.Diff([]int{1, 100, 100}, dst.Levels); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst.Levels)
+	}
+	if diff := cmp.Diff([]string{"public", "***"}, dst.Secrets); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst.Secrets)
+	}
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		invalid := func(string, reflect.Type, interface{}) (interface{}, bool) {
+			return []int{}, true
+		}
+		dst := 0
+		expErr := ErrorType{}
+		if err := ToFrom(&dst, 1, WithValueTransform(invalid)); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+}
+
+func TestAssignWithInterfaceReuse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("pointer", func(t *testing.T) {
+		t.Parallel()
+		small := &Small{Field: "0"}
+		dst := All{Interface: small}
+		src := map[string]interface{}{"Interface": map[string]interface{}{"Field": "1"}}
+		if err := ToFrom(&dst, src, WithMapToStruct(), WithInterfaceReuse()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if dst.Interface != small {
+			t.Errorf("expected the same pointer: %p but found: %v", small, dst.Interface)
+		}
+		if small.Field != "1" {
+			t.Errorf("expected field: %q but found: %q", "1", small.Field)
+		}
+	})
+	t.Run("value", func(t *testing.T) {
+		t.Parallel()
+		dst := All{Interface: 0}
+		src := All{Interface: 1.5}
+		if err := ToFrom(&dst, src, WithInterfaceReuse()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if diff := cmp.Diff(1, dst.Interface); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst.Interface)
+		}
+	})
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+		dst := All{Interface: &Small{Field: "0"}}
+		src := All{Interface: Small{Field: "1"}}
+		if err := ToFrom(&dst, src); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if diff := cmp.Diff(Small{Field: "1"}, dst.Interface); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst.Interface)
+		}
+	})
+}
+
+func TestAssignWithConverterFor(t *testing.T) {
+	t.Parallel()
+
+	type Celsius float64
+	type Code string
+	var calls []string
+	celsius := func(src interface{}) (interface{}, error) {
+		calls = append(calls, "celsius")
+		return (src.(float64) - 32) * 5 / 9, nil
+	}
+	code := func(src interface{}) (interface{}, error) {
+		calls = append(calls, "code")
+		s, ok := src.(string)
+		if !ok || s == "" {
+			return nil, fmt.Errorf("invalid code: %v", src)
+		}
+		return strings.ToUpper(s), nil
+	}
+	options := []Option{
+		WithConverterFor(reflect.TypeOf(Celsius(0)), celsius),
+		WithConverterFor(reflect.TypeOf(Code("")), code),
+	}
+
+	src := map[string]interface{}{"Temp": 212.0, "Code": "abc", "Name": "name"}
+	dst := struct {
+		Temp Celsius
+		Code Code
+		Name string
+	}{}
+	if err := ToFrom(&dst, src, append(options, WithMapToStruct())...); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if dst.Temp != 100 || dst.Code != "ABC" || dst.Name != "name" {
+		t.Errorf("expected converted values but found: %+v", dst)
+	}
+	if diff := cmp.Diff([]string{"celsius", "code"}, calls); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, calls)
+	}
+
+	t.Run("error", func(t *testing.T) {
+		dst := Code("")
+		expErr := ErrorConvert{}
+		if err := ToFrom(&dst, 1, options...); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+}
+
+// TestSetDefaults is not parallel as the defaults apply to the entire package.
+func TestSetDefaults(t *testing.T) {
+	defer SetDefaultTags()
+	defer SetDefaultOptions()
+
+	SetDefaultTags("json")
+	src := map[string]interface{}{"name": "Ada", "email": "ada@example.com", "password": "secret"}
+	dst := JSONUser{}
+	if err := ToFrom(&dst, src, WithMapToStruct()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	exp := JSONUser{Name: "Ada", Email: "ada@example.com"}
+	if diff := cmp.Diff(exp, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+
+	SetDefaultOptions(WithMapToStruct())
+	dst = JSONUser{}
+	if err := ToFrom(&dst, src); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if diff := cmp.Diff(exp, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+
+	SetDefaultTags()
+	SetDefaultOptions()
+	dst = JSONUser{}
+	if err := ToFrom(&dst, src); err == nil {
+		t.Errorf("expected error assigning a struct from a map by default")
+	}
+}
+
+// TestSetDefaultsConcurrent is not parallel as the defaults apply to the entire package.
+func TestSetDefaultsConcurrent(t *testing.T) {
+	defer SetDefaultTags()
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			SetDefaultTags("json")
+		}()
+		go func() {
+			defer wg.Done()
+			dst := Small{}
+			if err := ToFrom(&dst, Small{Field: "0"}); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestAssignPointerCollections(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		dst  interface{}
+		src  interface{}
+		exp  interface{}
+	}{
+		{
+			name: "slice",
+			dst:  new(*[]Small),
+			src:  []Small{{Field: "0"}, {Field: "1"}},
+			exp:  []Small{{Field: "0"}, {Field: "1"}},
+		},
+		{
+			name: "map",
+			dst:  new(*map[string]Small),
+			src:  map[string]Small{"a": {Field: "0"}},
+			exp:  map[string]Small{"a": {Field: "0"}},
+		},
+		{
+			name: "array",
+			dst:  new(*[2]Small),
+			src:  [2]Small{{Field: "0"}, {Field: "1"}},
+			exp:  [2]Small{{Field: "0"}, {Field: "1"}},
+		},
+		{
+			name: "slice to array",
+			dst:  new(*[2]Small),
+			src:  []Small{{Field: "0"}},
+			exp:  [2]Small{{Field: "0"}},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			if err := ToFrom(test.dst, test.src); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			dp := reflect.ValueOf(test.dst).Elem()
+			if dp.IsNil() {
+				t.Errorf("expected pointer to be allocated")
+				return
+			}
+			act := dp.Elem().Interface()
+			if diff := cmp.Diff(test.exp, act); diff != "" {
+				t.Errorf("(-expected +actual):\n%s\n%+v", diff, act)
+			}
+		})
+	}
+	t.Run("field", func(t *testing.T) {
+		t.Parallel()
+		src := struct{ PSlice []Small }{PSlice: []Small{{Field: "0"}}}
+		dst := All{}
+		if err := ToFrom(&dst, src); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if dst.PSlice == nil {
+			t.Errorf("expected pointer to be allocated")
+			return
+		}
+		if diff := cmp.Diff([]Small{{Field: "0"}}, *dst.PSlice); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, *dst.PSlice)
+		}
+	})
+}
+
+func TestAssignWithZeroDestination(t *testing.T) {
+	t.Parallel()
+
+	dst := Config{Name: "previous", Port: 80, Tags: []string{"a", "b"}, Owner: &Small{Field: "owner"}}
+	src := Config{Port: 8080, Tags: []string{"c"}}
+
+	kept := dst
+	if err := ToFrom(&kept, src); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if kept.Name != "previous" || kept.Owner == nil {
+		t.Errorf("expected previous values to be kept by default but found: %+v", kept)
+	}
+
+	if err := ToFrom(&dst, src, WithZeroDestination()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if diff := cmp.Diff(src, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+}
+
+func TestAssignRunes(t *testing.T) {
+	t.Parallel()
+
+	src := "héllo, 世界"
+	var runes []rune
+	if err := ToFrom(&runes, src); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if n := len(runes); n != 9 {
+		t.Errorf("expected code points: %d but found: %d", 9, n)
+	}
+	if diff := cmp.Diff([]rune(src), runes); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, runes)
+	}
+
+	act := ""
+	if err := ToFrom(&act, runes); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if act != src {
+		t.Errorf("expected value: %q but found: %q", src, act)
+	}
+
+	t.Run("named", func(t *testing.T) {
+		t.Parallel()
+		type Text []rune
+		dst := struct{ Text Text }{}
+		if err := ToFrom(&dst, struct{ Text string }{Text: "世界"}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if diff := cmp.Diff(Text("世界"), dst.Text); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst.Text)
+		}
+	})
+}
+
+func TestAssignWithCycleSkip(t *testing.T) {
+	t.Parallel()
+
+	src := struct {
+		Name  string
+		Cycle Cycle
+	}{Name: "graph", Cycle: newCycle()}
+	dst := struct {
+		Name  string
+		Cycle Cycle
+	}{}
+	stats, err := From(src, WithCycleSkip()).ToStats(&dst)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if dst.Name != "graph" {
+		t.Errorf("expected name: %q but found: %q", "graph", dst.Name)
+	}
+	if c := dst.Cycle.Struct; c == nil || c.Map == nil || c.Slice == nil || c.Array == nil {
+		t.Errorf("expected the fields of the cycle to be assigned but found: %+v", c)
+		return
+	}
+	// The sources are visited through Struct first, then skipped where they are shared.
+	exp := []string{
+		"Cycle.Struct.Struct",
+		"Cycle.Struct.Map[{}]",
+		"Cycle.Struct.Slice[0]",
+		"Cycle.Struct.Array[0]",
+		"Cycle.Map",
+		"Cycle.Slice",
+		"Cycle.Array",
+	}
+	if diff := cmp.Diff(exp, stats.Cycles); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, stats.Cycles)
+	}
+}
+
+func TestAssignWithAllocator(t *testing.T) {
+	t.Parallel()
+
+	counts := map[reflect.Type]int{}
+	alloc := func(t reflect.Type) reflect.Value {
+		counts[t]++
+		return reflect.New(t).Elem()
+	}
+	src := struct {
+		Map   map[string]Small
+		Slice []*Small
+	}{
+		Map:   map[string]Small{"a": {Field: "0"}, "b": {Field: "1"}},
+		Slice: []*Small{{Field: "2"}, {Field: "3"}, {Field: "4"}},
+	}
+	dst := struct {
+		Map   map[string]Small
+		Slice []*Small
+	}{}
+	if err := ToFrom(&dst, src, WithAllocator(alloc)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if diff := cmp.Diff(src, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+	exp := map[reflect.Type]int{
+		reflect.TypeOf(""):      2,
+		reflect.TypeOf(Small{}): 5,
+	}
+	if diff := cmp.Diff(exp, counts); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, counts)
+	}
+}
+
+func TestAssignStructKeys(t *testing.T) {
+	t.Parallel()
+
+	t.Run("struct", func(t *testing.T) {
+		t.Parallel()
+		src := map[Small]int{{Field: "a"}: 1, {Field: "b"}: 2}
+		dst := map[Small]int{}
+		if err := ToFrom(&dst, src); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if diff := cmp.Diff(src, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+	t.Run("convertible", func(t *testing.T) {
+		t.Parallel()
+		type Key struct{ Field string }
+		src := map[Key]int{{Field: "a"}: 1}
+		dst := map[Small]int{}
+		if err := ToFrom(&dst, src); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if diff := cmp.Diff(map[Small]int{{Field: "a"}: 1}, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+	t.Run("unhashable", func(t *testing.T) {
+		t.Parallel()
+		src := map[[2]int]int{{1, 2}: 3}
+		dst := map[interface{}]int{}
+		expErr := ErrorUnhashableKey{}
+		if err := ToFrom(&dst, src, WithInferInterfaceType()); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+			return
+		}
+		if expErr.Key.Kind() != reflect.Slice {
+			t.Errorf("expected kind: %v but found: %v", reflect.Slice, expErr.Key.Kind())
+		}
+	})
+	t.Run("interface", func(t *testing.T) {
+		t.Parallel()
+		src := map[string]int{"a": 1, "b": 2}
+		dst := map[interface{}]int{}
+		if err := ToFrom(&dst, src); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		for key, v := range src {
+			if dst[key] != v {
+				t.Errorf("expected value: %d of key: %q but found: %d", v, key, dst[key])
+			}
+		}
+		if len(dst) != len(src) {
+			t.Errorf("expected length: %d but found: %d", len(src), len(dst))
+		}
+	})
+	t.Run("sync", func(t *testing.T) {
+		t.Parallel()
+		src := map[[2]int]int{{1, 2}: 3}
+		dst := sync.Map{}
+		expErr := ErrorUnhashableKey{}
+		if err := ToFrom(&dst, src, WithInferInterfaceType()); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+}
+
+func TestAssignSubset(t *testing.T) {
+	t.Parallel()
+
+	model := UserModel{
+		ID:        1,
+		Email:     "ada@example.com",
+		Password:  "secret",
+		FirstName: "Ada",
+		LastName:  "Lovelace",
+		Address:   Address{City: "London"},
+	}
+
+	t.Run("subset", func(t *testing.T) {
+		t.Parallel()
+		dst := UserDTO{}
+		if err := ToFrom(&dst, model); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := UserDTO{ID: 1, Email: "ada@example.com", FirstName: "Ada"}
+		if diff := cmp.Diff(exp, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+	t.Run("unmatched", func(t *testing.T) {
+		t.Parallel()
+		var unmatched []string
+		warn := func(name string) {
+			unmatched = append(unmatched, name)
+		}
+		model := model
+		model.FirstName = ""
+		dst := UserDTO{}
+		if err := ToFrom(&dst, model, WithWarnUnmatchedDestination(warn)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if diff := cmp.Diff([]string{"Name", "City"}, unmatched); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, unmatched)
+		}
+	})
+}
+
+func TestAssignArrayPointerFromSlice(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  []Small
+		exp  *[2]Small
+	}{
+		{
+			name: "shorter",
+			src:  []Small{{Field: "0"}},
+			exp:  &[2]Small{{Field: "0"}},
+		},
+		{
+			name: "equal",
+			src:  []Small{{Field: "0"}, {Field: "1"}},
+			exp:  &[2]Small{{Field: "0"}, {Field: "1"}},
+		},
+		{
+			name: "longer",
+			src:  []Small{{Field: "0"}, {Field: "1"}, {Field: "2"}},
+			exp:  &[2]Small{{Field: "0"}, {Field: "1"}},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var act *[2]Small
+			if err := ToFrom(&act, test.src); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if diff := cmp.Diff(test.exp, act); diff != "" {
+				t.Errorf("(-expected +actual):\n%s\n%+v", diff, act)
+			}
+		})
+	}
+}
+
+func TestAssignReflectValue(t *testing.T) {
+	t.Parallel()
+
+	m := map[string]int{"a": 1}
+	s := []Small{{Field: "0"}}
+	arr := [2]Small{{Field: "0"}, {Field: "1"}}
+	ro := struct {
+		m map[string]int
+		s string
+	}{m: m, s: "s"}
+
+	tests := []struct {
+		name    string
+		src     reflect.Value
+		dst     interface{}
+		options []Option
+		exp     interface{}
+	}{
+		{
+			name: "map",
+			src:  reflect.ValueOf(m),
+			dst:  new(map[string]int),
+			exp:  map[string]int{"a": 1},
+		},
+		{
+			name: "addressable map",
+			src:  reflect.ValueOf(&m).Elem(),
+			dst:  new(map[string]int),
+			exp:  map[string]int{"a": 1},
+		},
+		{
+			name: "slice",
+			src:  reflect.ValueOf(s),
+			dst:  new([]Small),
+			exp:  []Small{{Field: "0"}},
+		},
+		{
+			name: "addressable slice",
+			src:  reflect.ValueOf(&s).Elem(),
+			dst:  new([]Small),
+			exp:  []Small{{Field: "0"}},
+		},
+		{
+			name: "array",
+			src:  reflect.ValueOf(arr),
+			dst:  new([]Small),
+			exp:  []Small{{Field: "0"}, {Field: "1"}},
+		},
+		{
+			name: "addressable array",
+			src:  reflect.ValueOf(&arr).Elem(),
+			dst:  new([]Small),
+			exp:  []Small{{Field: "0"}, {Field: "1"}},
+		},
+		{
+			name: "read-only map",
+			src:  reflect.ValueOf(ro).Field(0),
+			dst:  new(map[string]int),
+			exp:  map[string]int(nil),
+		},
+		{
+			name: "read-only string",
+			src:  reflect.ValueOf(ro).Field(1),
+			dst:  new(string),
+			exp:  "",
+		},
+		{
+			name:    "read-only map with unexported",
+			src:     reflect.ValueOf(ro).Field(0),
+			dst:     new(map[string]int),
+			options: []Option{WithUnsafeUnexported()},
+			exp:     map[string]int{"a": 1},
+		},
+		{
+			name:    "read-only string with unexported",
+			src:     reflect.ValueOf(ro).Field(1),
+			dst:     new(string),
+			options: []Option{WithUnsafeUnexported()},
+			exp:     "s",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			if err := ToFrom(test.dst, test.src, test.options...); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			act := reflect.ValueOf(test.dst).Elem().Interface()
+			if diff := cmp.Diff(test.exp, act); diff != "" {
+				t.Errorf("(-expected +actual):\n%s\n%+v", diff, act)
+			}
+		})
+	}
+
+	t.Run("pointer", func(t *testing.T) {
+		t.Parallel()
+		for _, val := range []interface{}{arr, Small{}, 1, "s"} {
+			if ptr := Of(val).Pointer(); ptr != 0 {
+				t.Errorf("expected pointer: 0 but found: %d for: %T", ptr, val)
+			}
+		}
+	})
+}
+
+func TestAssignWithIntegerStrict(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		src     interface{}
+		options []Option
+		exp     int
+		valid   bool
+	}{
+		{
+			name:  "whole",
+			src:   3.0,
+			exp:   3,
+			valid: true,
+		},
+		{
+			name:  "fractional without option",
+			src:   3.7,
+			exp:   3,
+			valid: true,
+		},
+		{
+			name:    "whole with option",
+			src:     3.0,
+			options: []Option{WithIntegerStrict()},
+			exp:     3,
+			valid:   true,
+		},
+		{
+			name:    "fractional with option",
+			src:     3.7,
+			options: []Option{WithIntegerStrict()},
+			valid:   false,
+		},
+		{
+			name:    "float32 with option",
+			src:     float32(-0.5),
+			options: []Option{WithIntegerStrict()},
+			valid:   false,
+		},
+		{
+			name:    "infinity with option",
+			src:     math.Inf(1),
+			options: []Option{WithIntegerStrict()},
+			valid:   false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			act := 0
+			err := ToFrom(&act, test.src, test.options...)
+			if !test.valid {
+				expErr := ErrorType{}
+				if !errors.As(err, &expErr) {
+					t.Errorf("expected type: %T but found: %T", expErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if act != test.exp {
+				t.Errorf("expected: %d but found: %d", test.exp, act)
+			}
+		})
+	}
+}
+
+func TestAssignWithPointerPresence(t *testing.T) {
+	t.Parallel()
+
+	zero, one := 0, 1
+	tests := []struct {
+		name    string
+		src     Patch
+		options []Option
+		exp     Patched
+	}{
+		{
+			name:    "zero",
+			src:     Patch{Retries: &zero},
+			options: []Option{WithPointerPresence()},
+			exp:     Patched{Retries: 0, Name: "name", Limit: &one},
+		},
+		{
+			name:    "nil",
+			src:     Patch{},
+			options: []Option{WithPointerPresence()},
+			exp:     Patched{Retries: 5, Name: "name", Limit: &one},
+		},
+		{
+			name:    "pointer to pointer",
+			src:     Patch{Limit: &zero},
+			options: []Option{WithPointerPresence()},
+			exp:     Patched{Retries: 5, Name: "name", Limit: &zero},
+		},
+		{
+			name:    "value",
+			src:     Patch{Retries: &one, Name: new(string)},
+			options: []Option{WithPointerPresence()},
+			exp:     Patched{Retries: 1, Name: "", Limit: &one},
+		},
+		{
+			name: "zero without option",
+			src:  Patch{Retries: &zero},
+			exp:  Patched{Retries: 5, Name: "name", Limit: &one},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			limit := 1
+			act := Patched{Retries: 5, Name: "name", Limit: &limit}
+			if err := ToFrom(&act, test.src, test.options...); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if diff := cmp.Diff(test.exp, act); diff != "" {
+				t.Errorf("(-expected +actual):\n%s\n%+v", diff, act)
+			}
+		})
+	}
+}
+
+func TestAssignWithMaxElements(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		src   interface{}
+		dst   interface{}
+		valid bool
+	}{
+		{
+			name:  "within",
+			src:   map[string][]int{"a": {1, 2}, "b": {3}},
+			dst:   new(map[string][]int),
+			valid: true,
+		},
+		{
+			name: "total",
+			src:  map[string][]int{"a": {1, 2}, "b": {3, 4}},
+			dst:  new(map[string][]int),
+		},
+		{
+			name: "slice",
+			src:  []int{1, 2, 3, 4, 5, 6},
+			dst:  new([]int),
+		},
+		{
+			name: "huge map",
+			src:  huge{Source: Of(map[string]int{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5, "f": 6})},
+			dst:  new(map[string]int),
+		},
+		{
+			name: "huge slice",
+			src:  huge{Source: Of([]int{1})},
+			dst:  new([]int),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := ToFrom(test.dst, test.src, WithMaxElements(5))
+			if test.valid {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			expErr := ErrorLimit{}
+			if !errors.As(err, &expErr) {
+				t.Errorf("expected type: %T but found: %T", expErr, err)
+				return
+			}
+			if expErr.Limit != 5 {
+				t.Errorf("expected limit: %d but found: %d", 5, expErr.Limit)
+			}
+		})
+	}
+}
+
+func TestAssignWithMaxStringLength(t *testing.T) {
+	t.Parallel()
+
+	type Comment struct {
+		Author string
+		Body   string
+	}
+	tests := []struct {
+		name     string
+		src      map[string]interface{}
+		options  []Option
+		expected Comment
+		err      bool
+	}{
+		{
+			name:     "within",
+			src:      map[string]interface{}{"Author": "ann", "Body": "hello"},
+			expected: Comment{Author: "ann", Body: "hello"},
+		},
+		{
+			name: "exceeds",
+			src:  map[string]interface{}{"Author": "ann", "Body": "hello, world"},
+			err:  true,
+		},
+		{
+			name:     "truncated",
+			src:      map[string]interface{}{"Author": "ann", "Body": "hello, world"},
+			options:  []Option{WithStringTruncation()},
+			expected: Comment{Author: "ann", Body: "hello"},
+		},
+		{
+			name:     "rune",
+			src:      map[string]interface{}{"Author": "ann", "Body": "héllo, wörld"},
+			options:  []Option{WithStringTruncation()},
+			expected: Comment{Author: "ann", Body: "héll"},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			options := append([]Option{WithMapToStruct(), WithMaxStringLength(5)}, test.options...)
+			dst := Comment{}
+			err := ToFrom(&dst, test.src, options...)
+			if test.err {
+				expErr := ErrorLimit{}
+				if !errors.As(err, &expErr) {
+					t.Errorf("expected type: %T but found: %T", expErr, err)
+				} else if expErr.Limit != 5 {
+					t.Errorf("expected limit: %d but found: %d", 5, expErr.Limit)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if diff := cmp.Diff(test.expected, dst); diff != "" {
+				t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+			}
+		})
+	}
+}
+
+func TestAssignWithErrorFromString(t *testing.T) {
+	t.Parallel()
+
+	src := map[string]interface{}{"ID": 1, "Err": "boom"}
+	dst := Failure{}
+	if err := ToFrom(&dst, src, WithMapToStruct(), WithErrorFromString()); err != nil {
 		t.Errorf("unexpected error: %v", err)
 		return
 	}
+	if dst.ID != 1 || dst.Err == nil || dst.Err.Error() != "boom" {
+		t.Errorf("expected error: %q but found: %v", "boom", dst.Err)
+		return
+	}
 
-	if diff := cmp.Diff(exp, dst); diff != "" {
-		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	back := FailureRecord{}
+	if err := ToFrom(&back, dst, WithErrorFromString()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
 	}
+	if diff := cmp.Diff(FailureRecord{ID: 1, Err: "boom"}, back); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, back)
+	}
+
+	t.Run("without option", func(t *testing.T) {
+		t.Parallel()
+		dst := Failure{}
+		expErr := ErrorType{}
+		if err := ToFrom(&dst, src, WithMapToStruct()); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
 }
-*/
 
-func TestAssignErrorType(t *testing.T) {
+func TestAssignWithFieldOrder(t *testing.T) {
 	t.Parallel()
 
-	fn := func() {}
-	ch := make(chan struct{})
+	// Derived is computed from the fields assigned before it.
+	priority := map[string]int{"Derived": 1}
 	tests := []struct {
-		name string
-		val  interface{}
+		name    string
+		options []Option
+		exp     Computed
 	}{
 		{
-			name: "bool",
-			val:  &allValue.Bool,
-		},
-		{
-			name: "string",
-			val:  &allValue.String,
-		},
-		{
-			name: "slice",
-			val:  &allValue.Slice,
-		},
-		{
-			name: "map",
-			val:  &allValue.Map,
-		},
-		{
-			name: "struct",
-			val:  &allValue.Small,
-		},
-		{
-			name: "func",
-			val:  &fn,
+			name: "declaration",
+			exp:  Computed{Derived: "", First: "a", Second: "b"},
 		},
 		{
-			name: "chan",
-			val:  &ch,
+			name: "derived last",
+			options: []Option{WithFieldOrder(func(a, b reflect.StructField) bool {
+				return priority[a.Name] < priority[b.Name]
+			})},
+			exp: Computed{Derived: "a,b", First: "a", Second: "b"},
 		},
 	}
 
-	expErr := ErrorType{}
-	for _, ti := range tests {
-		for _, tj := range tests {
-			if ti.name == tj.name {
-				continue
-			}
-			ti, tj := ti, tj
-			t.Run(fmt.Sprintf("assign to destination: %s from source: %s", ti.name, tj.name), func(t *testing.T) {
-				t.Parallel()
-				if err := ToFrom(ti.val, tj.val); !errors.As(err, &expErr) {
-					t.Errorf("expected type: %T but found: %T", expErr, err)
-				}
-			})
-			t.Run(fmt.Sprintf("assign to destination: %s from source: %s", tj.name, ti.name), func(t *testing.T) {
-				t.Parallel()
-				if err := ToFrom(tj.val, ti.val); !errors.As(err, &expErr) {
-					t.Errorf("expected type: %T but found: %T", expErr, err)
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var assigned []string
+			derive := WithValueTransform(func(path string, _ reflect.Type, src interface{}) (interface{}, bool) {
+				if path == "Derived" {
+					return strings.Join(assigned, ","), true
 				}
+				assigned = append(assigned, src.(string))
+				return nil, false
 			})
-		}
+			src := map[string]interface{}{"First": "a", "Second": "b", "Derived": "-"}
+			act := Computed{}
+			if err := ToFrom(&act, src, append(test.options, WithMapToStruct(), derive)...); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if diff := cmp.Diff(test.exp, act); diff != "" {
+				t.Errorf("(-expected +actual):\n%s\n%+v", diff, act)
+			}
+		})
 	}
 }
 
-func TestAssignNotPointerAndNilPointer(t *testing.T) {
+func TestAssignMapNilPointers(t *testing.T) {
 	t.Parallel()
-	dstAll := reflect.ValueOf(&All{}).Elem()
-	n := dstAll.NumField()
 
-	for i := 0; i < n; i++ {
-		i := i
-		typ := dstAll.Type()
-		name := typ.Field(i).Name
-		t.Run(name, func(t *testing.T) {
+	src := map[string]*Small{"nil": nil, "value": {Field: "value"}}
+	tests := []struct {
+		name string
+		dst  map[string]*Small
+	}{
+		{name: "nil"},
+		{name: "existing", dst: map[string]*Small{"nil": {Field: "existing"}, "kept": {Field: "kept"}}},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
 			t.Parallel()
-			df := dstAll.Field(i)
-			dt := df.Type()
-			if dt.Kind() == reflect.Ptr && df.IsNil() {
-				dt = reflect.TypeOf(nil)
+			dst := test.dst
+			if err := ToFrom(&dst, src); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
 			}
-			exp := newError(dt, reflect.Invalid)
-
-			if err := ToFrom(df, nil); err != exp {
-				t.Errorf("expected: %+v but found %+v", exp, err)
+			if v, ok := dst["nil"]; !ok || v != nil {
+				t.Errorf("expected nil value: %t but found: %+v", ok, v)
+			}
+			if v := dst["value"]; v == nil || *v != (Small{Field: "value"}) {
+				t.Errorf("expected value but found: %+v", v)
+			}
+			if v := src["value"]; dst["value"] == v {
+				t.Errorf("expected a copy of: %p but found: %p", v, dst["value"])
 			}
 		})
 	}
 }
 
-func TestAssignErrorCycle(t *testing.T) {
+func TestAssignInterfacePointer(t *testing.T) {
 	t.Parallel()
-	srcCycle := reflect.ValueOf(newCycle())
-	dstCycle := reflect.ValueOf(&Cycle{}).Elem()
-	expErr := ErrorCycle{}
 
-	n := srcCycle.NumField()
-	for i := 0; i < n; i++ {
-		i := i
-		typ := srcCycle.Type()
-		name := typ.Field(i).Name
-		t.Run(name, func(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      interface{}
+		expected Small
+	}{
+		{name: "pointer", src: []interface{}{&Small{Field: "value"}}, expected: Small{Field: "value"}},
+		{name: "nested", src: []interface{}{func() interface{} { s := &Small{Field: "value"}; return &s }()}, expected: Small{Field: "value"}},
+		{name: "nil", src: []interface{}{(*Small)(nil)}, expected: Small{Field: "kept"}},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
 			t.Parallel()
-			df := dstCycle.Field(i)
-			sf := srcCycle.Field(i)
-
-			if err := ToFrom(df.Addr(), sf); !errors.As(err, &expErr) {
-				t.Errorf("expected type: %T but found: %T", expErr, err)
+			dst := []Small{{Field: "kept"}}
+			if err := ToFrom(&dst, test.src); err != nil {
+				t.Errorf("unexpected error: %v", err)
 				return
 			}
+			if diff := cmp.Diff(test.expected, dst[0]); diff != "" {
+				t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst[0])
+			}
 		})
 	}
-}
-
-func TestAssignErrorPanic(t *testing.T) {
-	t.Parallel()
-
-	src := panicker{Source: Of(&allValue)}
-	expErr := ErrorPanic{}
-	// Verify cyclical checks are enabled as Pointer panics.
-	if err := ToFrom(&All{}, src); !errors.As(err, &expErr) {
-		t.Errorf("expected type: %T but found: %T", expErr, err)
-		return
-	}
+	t.Run("root", func(t *testing.T) {
+		t.Parallel()
+		var src interface{} = &Small{Field: "value"}
+		dst := Small{}
+		if err := ToFrom(&dst, Of(reflect.ValueOf(&src).Elem())); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if diff := cmp.Diff(Small{Field: "value"}, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
 }
 
 type All struct {
@@ -517,11 +3390,22 @@ type ListsDestination struct {
 	ArrayToSliceShort []interface{}
 }
 
+type ListsAppend struct {
+	Slice []Small
+	Array [2]string
+}
+
 type Fields struct {
 	Exported    string
 	notExported int
 }
 
+type Internal struct {
+	small *Small
+	list  []int
+	dict  map[string]int
+}
+
 type Tags struct {
 	FooGoToBar string `assign:"BarGoToFoo",json:"FooGoToBar"`
 	BarGoToFoo string `assign:"FooGoToBar"json:"BarGoToFoo"`
@@ -561,3 +3445,298 @@ func (panicker) Pointer() uintptr {
 }
 
 var _ Source = (*panicker)(nil)
+
+// Envelope contains a polymorphic payload.
+type Envelope struct {
+	Kind    string
+	Payload interface{}
+}
+
+type Event struct {
+	Name  string
+	Count int
+}
+
+// Node is a recursive type.
+type Node struct {
+	Name string
+	Next *Node
+}
+
+// recursive is a struct Source with a field that is an interface
+// of a fresh copy of itself, which has no pointer to visit.
+type recursive struct {
+	Source
+}
+
+func (recursive) Kind() reflect.Kind {
+	return reflect.Struct
+}
+
+func (recursive) FieldByName(name string) Source {
+	if name == "Next" {
+		return recursiveInterface{}
+	}
+	return Of(name)
+}
+
+func (recursive) Skip() bool {
+	return false
+}
+
+// recursiveInterface is an interface Source of a recursive value.
+type recursiveInterface struct {
+	recursive
+}
+
+func (recursiveInterface) Kind() reflect.Kind {
+	return reflect.Interface
+}
+
+func (recursiveInterface) Elem() Source {
+	return recursive{}
+}
+
+var _ Source = (*recursive)(nil)
+
+// sortedSource is a map Source with a MapIter of sorted keys.
+// The keys are recorded in the order they are consumed.
+type sortedSource struct {
+	Source
+	keys []string
+}
+
+func (s *sortedSource) Skip() bool {
+	return false
+}
+
+func (s *sortedSource) MapRange() MapIter {
+	keys := []string{}
+	vals := map[string]Source{}
+	for mi := s.Source.MapRange(); mi.Next(); {
+		key := mi.Key().Interface().(string)
+		keys = append(keys, key)
+		vals[key] = mi.Value()
+	}
+	sort.Strings(keys)
+	return &sortedMapIter{src: s, keys: keys, vals: vals, i: -1}
+}
+
+// sortedMapIter is a MapIter of sorted keys.
+type sortedMapIter struct {
+	src  *sortedSource
+	keys []string
+	vals map[string]Source
+	i    int
+}
+
+func (m *sortedMapIter) Next() bool {
+	m.i++
+	return m.i < len(m.keys)
+}
+
+func (m *sortedMapIter) Key() Source {
+	key := m.keys[m.i]
+	m.src.keys = append(m.src.keys, key)
+	return Of(key)
+}
+
+func (m *sortedMapIter) Value() Source {
+	return m.vals[m.keys[m.i]]
+}
+
+var _ MapIter = (*sortedMapIter)(nil)
+
+// Status is an enum type.
+type Status int
+
+const (
+	StatusUnknown Status = iota
+	StatusActive
+	StatusInactive
+)
+
+type Account struct {
+	Name   string
+	Status Status
+}
+
+// Name is a named string type.
+type Name string
+
+type Person struct {
+	Name    string            `assign:"name"`
+	Address Address           `assign:"address"`
+	Labels  map[string]string `assign:"labels"`
+}
+
+type Address struct {
+	City string `assign:"city"`
+	Zip  string `assign:"zip"`
+	Geo  *Geo   `assign:"geo"`
+}
+
+type Geo struct {
+	Lat float64 `assign:"lat"`
+}
+
+// Shape is a polymorphic interface.
+type Shape interface {
+	Area() float64
+}
+
+type Circle struct {
+	Type   string `assign:"type"`
+	Radius float64
+}
+
+func (c *Circle) Area() float64 {
+	return 3 * c.Radius * c.Radius
+}
+
+type Square struct {
+	Type string `assign:"type"`
+	Side float64
+}
+
+func (s Square) Area() float64 {
+	return s.Side * s.Side
+}
+
+// opaque is a Source which hides the underlying Source type, including all of its children.
+type opaque struct {
+	Source
+}
+
+func (o opaque) Elem() Source {
+	return opaque{Source: o.Source.Elem()}
+}
+
+func (o opaque) FieldByName(name string) Source {
+	return opaque{Source: o.Source.FieldByName(name)}
+}
+
+func (o opaque) Index(i int) Source {
+	return opaque{Source: o.Source.Index(i)}
+}
+
+func (o opaque) MapRange() MapIter {
+	return opaqueMapIter{MapIter: o.Source.MapRange()}
+}
+
+var _ Source = (*opaque)(nil)
+
+// opaqueMapIter is a MapIter which hides the underlying Source types.
+type opaqueMapIter struct {
+	MapIter
+}
+
+func (m opaqueMapIter) Key() Source {
+	return opaque{Source: m.MapIter.Key()}
+}
+
+func (m opaqueMapIter) Value() Source {
+	return opaque{Source: m.MapIter.Value()}
+}
+
+var _ MapIter = (*opaqueMapIter)(nil)
+
+// User has getters for UserView.
+type User struct {
+	First string
+	Last  string
+	birth int
+}
+
+var errNoBirth = errors.New("no birth")
+
+func (u User) FullName() string {
+	return u.First + " " + u.Last
+}
+
+func (u User) Age() (int, error) {
+	if u.birth == 0 {
+		return 0, errNoBirth
+	}
+	return 1852 - u.birth, nil
+}
+
+func (u *User) Initials() string {
+	return u.First[:1] + u.Last[:1]
+}
+
+type UserView struct {
+	FullName string
+	Age      int
+	Initials string
+}
+
+type Headers map[string][]string
+
+type IDs []int
+
+// UserModel is a model with more fields than UserDTO.
+type UserModel struct {
+	ID        int
+	Email     string
+	Password  string
+	FirstName string
+	LastName  string
+	Address   Address
+}
+
+// UserDTO is a subset of UserModel with mismatched field names.
+type UserDTO struct {
+	ID        int
+	Email     string
+	FirstName string
+	Name      string
+	City      string
+}
+
+type Patch struct {
+	Retries *int
+	Name    *string
+	Limit   *int
+}
+
+type Patched struct {
+	Retries int
+	Name    string
+	Limit   *int
+}
+
+// huge is a Source that reports a length far beyond its elements, as untrusted sources may.
+type huge struct {
+	Source
+}
+
+func (h huge) Len() int {
+	return math.MaxInt64 >> 8
+}
+
+type Failure struct {
+	ID  int
+	Err error
+}
+
+type FailureRecord struct {
+	ID  int
+	Err string
+}
+
+type Computed struct {
+	Derived string
+	First   string
+	Second  string
+}
+
+type Normalized struct {
+	UserID      int
+	CreatedAt   time.Time
+	HomeAddress *StreetAddress
+}
+
+type StreetAddress struct {
+	StreetName string
+}
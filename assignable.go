@@ -0,0 +1,32 @@
+package assign
+
+import "reflect"
+
+// Assignable is implemented by destination types that assign themselves from any source.
+// The assignment of the destination is delegated entirely to AssignFrom,
+// the fields, keys or elements of the destination are not assigned by the package.
+type Assignable interface {
+	// AssignFrom assigns the destination from the source.
+	AssignFrom(src Source) error
+}
+
+var assignableType = reflect.TypeOf((*Assignable)(nil)).Elem()
+
+// assignableOf provides the Assignable of the addressable destination.
+// The result is false when the destination does not implement it with a pointer receiver.
+func assignableOf(dv reflect.Value) (Assignable, bool) {
+	if !dv.CanAddr() || !reflect.PtrTo(dv.Type()).Implements(assignableType) {
+		return nil, false
+	}
+	return dv.Addr().Interface().(Assignable), true
+}
+
+// assignAssignable assigns the destination from the source with AssignFrom.
+// The error of AssignFrom is returned as is.
+func (a *Assigner) assignAssignable(u Assignable, sv Source, md *metadata) error {
+	if err := u.AssignFrom(sv); err != nil {
+		return err
+	}
+	md.stats.Assigned++
+	return nil
+}
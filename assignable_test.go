@@ -0,0 +1,71 @@
+package assign
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAssignAssignable(t *testing.T) {
+	t.Parallel()
+
+	t.Run("bespoke", func(t *testing.T) {
+		t.Parallel()
+		src := map[string]interface{}{"Name": "Ada Lovelace", "Alias": "Countess of Lovelace"}
+		dst := Contact{}
+		stats, err := From(src, WithMapToStruct()).ToStats(&dst)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := Contact{
+			Name:  &FullName{First: "Ada", Last: "Lovelace"},
+			Alias: FullName{First: "Countess", Last: "of Lovelace"},
+		}
+		if diff := cmp.Diff(exp, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+		if stats.Assigned != 2 {
+			t.Errorf("expected assigned: %d but found: %d", 2, stats.Assigned)
+		}
+	})
+	t.Run("bypassed", func(t *testing.T) {
+		t.Parallel()
+		src := map[string]interface{}{"Alias": map[string]interface{}{"First": "Ada", "Last": "Lovelace"}}
+		dst := Contact{}
+		if err := ToFrom(&dst, src, WithMapToStruct()); !errors.Is(err, errFullName) {
+			t.Errorf("expected error: %v but found: %v", errFullName, err)
+		}
+	})
+	t.Run("can assign", func(t *testing.T) {
+		t.Parallel()
+		if err := CanAssign(reflect.TypeOf(FullName{}), reflect.TypeOf(0)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+type Contact struct {
+	Name  *FullName
+	Alias FullName
+}
+
+// FullName is assigned from a string of the first and last names.
+type FullName struct {
+	First string
+	Last  string
+}
+
+var errFullName = errors.New("full name must be a string")
+
+func (n *FullName) AssignFrom(src Source) error {
+	s, ok := src.Interface().(string)
+	if !ok {
+		return errFullName
+	}
+	n.First, n.Last, _ = strings.Cut(s, " ")
+	return nil
+}
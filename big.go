@@ -0,0 +1,154 @@
+package assign
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+)
+
+var (
+	bigIntType = reflect.TypeOf(big.Int{})
+	bigRatType = reflect.TypeOf(big.Rat{})
+
+	bigSet = map[reflect.Type]struct{}{
+		bigIntType: {},
+		bigRatType: {},
+	}
+)
+
+// assignToBig assigns to a big.Int or big.Rat from numbers, strings or big numbers.
+func (a *Assigner) assignToBig(db reflect.Value, sb Source, md *metadata) error {
+	sv := a.valueOf(sb)
+	var ok bool
+	switch x := db.Addr().Interface().(type) {
+	case *big.Int:
+		ok = setBigInt(x, sv)
+	case *big.Rat:
+		ok = setBigRat(x, sv)
+	}
+	if !ok {
+		return newError(db.Type(), sv.Kind())
+	}
+	md.stats.Assigned++
+	return nil
+}
+
+// setBigInt sets the big.Int from the value and reports whether it is set.
+// Floats and big.Rat values must be integers.
+func setBigInt(x *big.Int, sv reflect.Value) bool {
+	switch sv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x.SetInt64(sv.Int())
+		return true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		x.SetUint64(sv.Uint())
+		return true
+	case reflect.Float32, reflect.Float64:
+		if math.IsNaN(sv.Float()) {
+			return false
+		}
+		f := big.NewFloat(sv.Float())
+		if !f.IsInt() {
+			return false
+		}
+		f.Int(x)
+		return true
+	case reflect.String:
+		_, ok := x.SetString(sv.String(), 10)
+		return ok
+	}
+	switch y := sv.Interface().(type) {
+	case big.Int:
+		x.Set(&y)
+		return true
+	case big.Rat:
+		if !y.IsInt() {
+			return false
+		}
+		x.Set(y.Num())
+		return true
+	}
+	return false
+}
+
+// setBigRat sets the big.Rat from the value and reports whether it is set.
+func setBigRat(x *big.Rat, sv reflect.Value) bool {
+	switch sv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x.SetInt64(sv.Int())
+		return true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		x.SetInt(new(big.Int).SetUint64(sv.Uint()))
+		return true
+	case reflect.Float32, reflect.Float64:
+		return x.SetFloat64(sv.Float()) != nil
+	case reflect.String:
+		_, ok := x.SetString(sv.String())
+		return ok
+	}
+	switch y := sv.Interface().(type) {
+	case big.Int:
+		x.SetInt(&y)
+		return true
+	case big.Rat:
+		x.Set(&y)
+		return true
+	}
+	return false
+}
+
+// isBigNumeric reports whether the kind is a number or string assigned from big.Int or big.Rat, see assignFromBig.
+// Other kinds are assigned from big numbers as any other value, e.g. interfaces.
+func isBigNumeric(dk reflect.Kind) bool {
+	return isKind(intSet, dk) || isKind(uintSet, dk) || isKind(floatSet, dk) || dk == reflect.String
+}
+
+// assignFromBig assigns a big.Int or big.Rat to numbers or strings.
+// Integers that overflow the destination result in ErrorOverflow.
+func (a *Assigner) assignFromBig(db reflect.Value, sv reflect.Value, md *metadata) error {
+	dt := db.Type()
+	var x big.Rat
+	switch y := sv.Interface().(type) {
+	case big.Int:
+		x.SetInt(&y)
+	case big.Rat:
+		x.Set(&y)
+	}
+
+	switch dk := dt.Kind(); dk {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !x.IsInt() {
+			return newError(dt, sv.Kind())
+		}
+		n := x.Num()
+		if !n.IsInt64() || db.OverflowInt(n.Int64()) {
+			return newErrorOverflow(dt, x.RatString())
+		}
+		db.SetInt(n.Int64())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if !x.IsInt() {
+			return newError(dt, sv.Kind())
+		}
+		n := x.Num()
+		if !n.IsUint64() || db.OverflowUint(n.Uint64()) {
+			return newErrorOverflow(dt, x.RatString())
+		}
+		db.SetUint(n.Uint64())
+	case reflect.Float32, reflect.Float64:
+		f, _ := x.Float64()
+		if db.OverflowFloat(f) {
+			return newErrorOverflow(dt, x.RatString())
+		}
+		db.SetFloat(f)
+	case reflect.String:
+		if x.IsInt() {
+			db.SetString(x.Num().String())
+		} else {
+			db.SetString(x.RatString())
+		}
+	default:
+		return newError(dt, sv.Kind())
+	}
+	md.stats.Assigned++
+	return nil
+}
@@ -0,0 +1,139 @@
+package assign
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestAssignBigInt(t *testing.T) {
+	t.Parallel()
+
+	t.Run("int64 to big.Int", func(t *testing.T) {
+		t.Parallel()
+		var dst *big.Int
+		if err := ToFrom(&dst, int64(math.MaxInt64)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if exp := big.NewInt(math.MaxInt64); dst.Cmp(exp) != 0 {
+			t.Errorf("expected: %v but found %v", exp, dst)
+		}
+	})
+	t.Run("big.Int to int64", func(t *testing.T) {
+		t.Parallel()
+		var dst int64
+		if err := ToFrom(&dst, big.NewInt(-42)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if exp := int64(-42); dst != exp {
+			t.Errorf("expected: %v but found %v", exp, dst)
+		}
+	})
+	t.Run("big.Int to big.Int", func(t *testing.T) {
+		t.Parallel()
+		dst := BigFields{}
+		src := BigFields{Int: big.NewInt(7)}
+		if err := ToFrom(&dst, src); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if dst.Int == src.Int || dst.Int.Cmp(src.Int) != 0 {
+			t.Errorf("expected a copy of: %v but found %v", src.Int, dst.Int)
+		}
+	})
+	t.Run("big.Int overflows int8", func(t *testing.T) {
+		t.Parallel()
+		var dst int8
+		expErr := ErrorOverflow{}
+		if err := ToFrom(&dst, big.NewInt(128)); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+	t.Run("negative big.Int overflows uint", func(t *testing.T) {
+		t.Parallel()
+		var dst uint
+		expErr := ErrorOverflow{}
+		if err := ToFrom(&dst, big.NewInt(-1)); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+	t.Run("big.Int to interface", func(t *testing.T) {
+		t.Parallel()
+		var dst interface{}
+		if err := ToFrom(&dst, big.NewInt(5)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		x, ok := dst.(big.Int)
+		if !ok || x.Int64() != 5 {
+			t.Errorf("expected value: %v but found: %#v", big.NewInt(5), dst)
+		}
+	})
+	t.Run("unexported field to big.Int", func(t *testing.T) {
+		t.Parallel()
+		dst := struct{ N *big.Int }{}
+		src := struct{ n int64 }{n: 9}
+		if err := ToFrom(&dst, src, WithUnsafeUnexported(), WithFieldNameNormalizer(strings.ToLower)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if exp := big.NewInt(9); dst.N == nil || dst.N.Cmp(exp) != 0 {
+			t.Errorf("expected: %v but found %v", exp, dst.N)
+		}
+	})
+	t.Run("fractional float to big.Int", func(t *testing.T) {
+		t.Parallel()
+		var dst big.Int
+		expErr := ErrorType{}
+		if err := ToFrom(&dst, 1.5); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+}
+
+func TestAssignBigRat(t *testing.T) {
+	t.Parallel()
+
+	t.Run("float to big.Rat", func(t *testing.T) {
+		t.Parallel()
+		var dst *big.Rat
+		if err := ToFrom(&dst, 0.75); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if exp := big.NewRat(3, 4); dst.Cmp(exp) != 0 {
+			t.Errorf("expected: %v but found %v", exp, dst)
+		}
+	})
+	t.Run("big.Rat to float", func(t *testing.T) {
+		t.Parallel()
+		var dst float64
+		if err := ToFrom(&dst, big.NewRat(1, 4)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if exp := 0.25; dst != exp {
+			t.Errorf("expected: %v but found %v", exp, dst)
+		}
+	})
+	t.Run("big.Rat to string", func(t *testing.T) {
+		t.Parallel()
+		var dst string
+		if err := ToFrom(&dst, big.NewRat(1, 3)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if exp := "1/3"; dst != exp {
+			t.Errorf("expected: %v but found %v", exp, dst)
+		}
+	})
+}
+
+type BigFields struct {
+	Int *big.Int
+	Rat *big.Rat
+}
@@ -0,0 +1,83 @@
+package assign
+
+import (
+	"math"
+	"reflect"
+)
+
+// assignBitFlags assigns between bitmasks and maps of named bools, see WithBitFlags.
+// Integers are expanded to a map of string keys to bool values with every name of the flags.
+// Maps of names to bools are packed to integers, unknown names result in ErrorEnum.
+// The handled result is false for other destinations and sources.
+func (a *Assigner) assignBitFlags(dv reflect.Value, sv Source, md *metadata) (bool, error) {
+	dt := dv.Type()
+	sk := sv.Kind()
+	switch dk := dt.Kind(); {
+	case dk == reflect.Map && (isKind(intSet, sk) || isKind(uintSet, sk)):
+		if dt.Key().Kind() != reflect.String || dt.Elem().Kind() != reflect.Bool {
+			return false, nil
+		}
+		bits := bitsOf(a.valueOf(sv))
+		if dv.IsNil() {
+			dv.Set(reflect.MakeMapWithSize(dt, len(a.bitFlags)))
+		}
+		for name, flag := range a.bitFlags {
+			key := a.newValue(dt.Key())
+			key.SetString(name)
+			set := a.newValue(dt.Elem())
+			set.SetBool(bits&uint64(flag) != 0)
+			dv.SetMapIndex(key, set)
+		}
+	case (isKind(intSet, dk) || isKind(uintSet, dk)) && sk == reflect.Map:
+		var bits uint64
+		for mi := sv.MapRange(); mi.Next(); {
+			key := a.valueOf(interfaceElem(mi.Key()))
+			if key.Kind() != reflect.String {
+				return true, newError(dt, key.Kind())
+			}
+			name := key.String()
+			flag, ok := a.bitFlags[name]
+			if !ok {
+				return true, newErrorEnum(dt, name)
+			}
+			set := a.valueOf(interfaceElem(mi.Value()))
+			if set.Kind() != reflect.Bool {
+				return true, newError(dt, set.Kind())
+			}
+			if set.Bool() {
+				bits |= uint64(flag)
+			}
+		}
+		if isKind(intSet, dk) {
+			if bits > math.MaxInt64 || dv.OverflowInt(int64(bits)) {
+				return true, newErrorOverflow(dt, bits)
+			}
+			dv.SetInt(int64(bits))
+		} else {
+			if dv.OverflowUint(bits) {
+				return true, newErrorOverflow(dt, bits)
+			}
+			dv.SetUint(bits)
+		}
+	default:
+		return false, nil
+	}
+	md.stats.Assigned++
+	return true, nil
+}
+
+// interfaceElem unwraps the interfaces of the source, e.g. values of a map[string]interface{}.
+func interfaceElem(s Source) Source {
+	for s.Kind() == reflect.Interface {
+		s = s.Elem()
+	}
+	return s
+}
+
+// bitsOf provides the bits of the integer.
+func bitsOf(sv reflect.Value) uint64 {
+	if isKind(intSet, sv.Kind()) {
+		return uint64(sv.Int())
+	}
+	return sv.Uint()
+}
@@ -0,0 +1,102 @@
+package assign
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAssignWithBitFlags(t *testing.T) {
+	t.Parallel()
+
+	flags := WithBitFlags(map[string]int{"read": 1 << 0, "write": 1 << 1, "exec": 1 << 2})
+
+	t.Run("expand", func(t *testing.T) {
+		t.Parallel()
+		var act map[string]bool
+		if err := ToFrom(&act, 0b101, flags); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := map[string]bool{"read": true, "write": false, "exec": true}
+		if diff := cmp.Diff(exp, act); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, act)
+		}
+
+		var back uint8
+		if err := ToFrom(&back, act, flags); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if back != 0b101 {
+			t.Errorf("expected: %b but found: %b", 0b101, back)
+		}
+	})
+	t.Run("fields", func(t *testing.T) {
+		t.Parallel()
+		act := Permissions{}
+		if err := ToFrom(&act, map[string]interface{}{"Mask": 0b011}, flags, WithMapToStruct()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := Permissions{Mask: map[string]bool{"read": true, "write": true, "exec": false}}
+		if diff := cmp.Diff(exp, act); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, act)
+		}
+	})
+	t.Run("unknown", func(t *testing.T) {
+		t.Parallel()
+		act := 0
+		expErr := ErrorEnum{}
+		if err := ToFrom(&act, map[string]bool{"delete": true}, flags); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+	t.Run("named keys", func(t *testing.T) {
+		t.Parallel()
+		type Perm string
+		act := 0
+		if err := ToFrom(&act, map[Perm]bool{"read": true, "exec": true}, flags); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if act != 0b101 {
+			t.Errorf("expected: %b but found: %b", 0b101, act)
+		}
+	})
+	t.Run("nested interfaces", func(t *testing.T) {
+		t.Parallel()
+		act := struct{ Mask uint8 }{}
+		src := struct{ Mask interface{} }{Mask: map[string]interface{}{"read": true, "write": false}}
+		if err := ToFrom(&act, src, flags); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if act.Mask != 0b001 {
+			t.Errorf("expected: %b but found: %b", 0b001, act.Mask)
+		}
+	})
+	t.Run("sign bit", func(t *testing.T) {
+		t.Parallel()
+		high := WithBitFlags(map[string]int{"high": math.MinInt64})
+		var act int64
+		expErr := ErrorOverflow{}
+		if err := ToFrom(&act, map[string]bool{"high": true}, high); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+		var back uint64
+		if err := ToFrom(&back, map[string]bool{"high": true}, high); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if back != 1<<63 {
+			t.Errorf("expected: %b but found: %b", uint64(1<<63), back)
+		}
+	})
+}
+
+type Permissions struct {
+	Mask map[string]bool
+}
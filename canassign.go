@@ -0,0 +1,206 @@
+package assign
+
+import "reflect"
+
+// CanAssign reports whether values of the source type can be assigned to the destination type with options.
+// The types are walked structurally by fields, keys and elements without values,
+// the first incompatibility results in ErrorType.
+// Assignments that depend on values are assumed compatible, e.g. parsing strings, interfaces and custom converters.
+// See Assigner.To for additional details.
+func CanAssign(dstType, srcType reflect.Type, options ...Option) error {
+	if dstType == nil || srcType == nil {
+		return newError(dstType, reflect.Invalid)
+	}
+	a := From(nil, options...)
+	return a.canAssign(dstType, srcType, map[[2]reflect.Type]struct{}{})
+}
+
+// canAssign walks the destination and source types as assignValue walks values.
+// Seen pairs of types are compatible as they are being walked, which handles recursive types.
+func (a *Assigner) canAssign(dt, st reflect.Type, seen map[[2]reflect.Type]struct{}) error {
+	pair := [2]reflect.Type{dt, st}
+	if _, ok := seen[pair]; ok {
+		return nil
+	}
+	seen[pair] = struct{}{}
+
+	if st.Kind() == reflect.Interface || reflect.PtrTo(dt).Implements(assignableType) {
+		return nil
+	}
+	if a.sharing && st.Kind() == reflect.Ptr && st == dt {
+		return nil
+	}
+	if st.Kind() == reflect.Ptr {
+		return a.canAssign(dt, st.Elem(), seen)
+	}
+	if _, ok := a.converters[dt]; ok {
+		return nil
+	}
+	if len(a.valueTrans) > 0 {
+		return nil
+	}
+	if a.rawMessage && dt == rawMessageType {
+		return nil
+	}
+	if dt.Kind() == reflect.Interface {
+		if st.Implements(dt) || a.ifaceTypes != nil || a.discTypes != nil || a.ifaceReuse {
+			return nil
+		}
+		return newError(dt, st.Kind())
+	}
+	if _, ok := bigSet[dt]; ok {
+		return nil
+	}
+	switch dt {
+	case timeType, syncMapType, orderedMapType:
+		return nil
+	}
+	if a.binary && isBytes(st) {
+		if _, ok := binaryUnmarshaler(reflect.New(dt).Elem()); ok {
+			return nil
+		}
+	}
+
+	switch dt.Kind() {
+	case reflect.Ptr:
+		return a.canAssign(dt.Elem(), st, seen)
+	case reflect.Struct:
+		return a.canAssignStruct(dt, st, seen)
+	case reflect.Map:
+		if st.Kind() == reflect.Struct && a.structMaps {
+			if dt.Key().Kind() != reflect.String {
+				return newError(dt, st.Kind())
+			}
+			return a.canAssignFromFields(dt.Elem(), st, seen)
+		}
+		if st.Kind() != reflect.Map {
+			return newError(dt, st.Kind())
+		}
+		if err := a.canAssign(dt.Key(), st.Key(), seen); err != nil {
+			return err
+		}
+		return a.canAssign(dt.Elem(), st.Elem(), seen)
+	case reflect.Slice, reflect.Array:
+		return a.canAssignList(dt, st, seen)
+	case reflect.Chan:
+		if a.chanFill {
+			return a.canAssignList(dt, st, seen)
+		}
+	}
+	return a.canAssignBasic(dt, st)
+}
+
+// canAssignStruct walks the fields of the destination struct matched by name to the source.
+// Fields that are not matched are compatible as they are skipped.
+func (a *Assigner) canAssignStruct(dt, st reflect.Type, seen map[[2]reflect.Type]struct{}) error {
+	sk := st.Kind()
+	if _, ok := listSet[sk]; ok && a.listStructs {
+		return a.canAssignToFields(dt, st.Elem(), seen)
+	}
+	if sk == reflect.Map && a.mapStructs {
+		if st.Key().Kind() != reflect.String {
+			return newError(dt, sk)
+		}
+		return a.canAssignToFields(dt, st.Elem(), seen)
+	}
+	if sk != reflect.Struct {
+		return newError(dt, sk)
+	}
+	for i := 0; i < dt.NumField(); i++ {
+		dsf := dt.Field(i)
+		if !dsf.IsExported() && !a.unexported {
+			continue
+		}
+		tag := a.tagOf(dsf)
+		if tag.ignored {
+			continue
+		}
+		ssf, ok := st.FieldByName(tag.name)
+		if !ok || !ssf.IsExported() && !a.unexported {
+			continue
+		}
+		if err := a.canAssign(dsf.Type, ssf.Type, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// canAssignToFields walks the fields of the destination struct from the source type of every field,
+// e.g. the element of a map or slice.
+func (a *Assigner) canAssignToFields(dt, st reflect.Type, seen map[[2]reflect.Type]struct{}) error {
+	for i := 0; i < dt.NumField(); i++ {
+		dsf := dt.Field(i)
+		if !dsf.IsExported() && !a.unexported || a.tagOf(dsf).ignored {
+			continue
+		}
+		if err := a.canAssign(dsf.Type, st, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// canAssignFromFields walks the fields of the source struct to the destination type of every field,
+// e.g. the element of a map or slice.
+func (a *Assigner) canAssignFromFields(dt, st reflect.Type, seen map[[2]reflect.Type]struct{}) error {
+	for i := 0; i < st.NumField(); i++ {
+		ssf := st.Field(i)
+		if !ssf.IsExported() || a.tagOf(ssf).ignored {
+			continue
+		}
+		if err := a.canAssign(dt, ssf.Type, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// canAssignList walks the elements of the destination slice, array or channel.
+func (a *Assigner) canAssignList(dt, st reflect.Type, seen map[[2]reflect.Type]struct{}) error {
+	sk := st.Kind()
+	if sk == reflect.Map && a.mapIndexed && dt.Kind() != reflect.Chan {
+		return a.canAssign(dt.Elem(), st.Elem(), seen)
+	}
+	if sk == reflect.String && dt.Kind() == reflect.Slice {
+		if a.base64 && isBytes(dt) || dt.Elem().Kind() == reflect.Int32 {
+			return a.canAssignBasic(dt, st)
+		}
+	}
+	if sk == reflect.Struct && a.structLists && dt.Kind() != reflect.Chan {
+		return a.canAssignFromFields(dt.Elem(), st, seen)
+	}
+	if _, ok := scalarSet[sk]; ok && a.scalarList && dt.Kind() != reflect.Chan {
+		return a.canAssign(dt.Elem(), st, seen)
+	}
+	if _, ok := listSet[sk]; !ok {
+		return newError(dt, sk)
+	}
+	return a.canAssign(dt.Elem(), st.Elem(), seen)
+}
+
+// canAssignBasic reports the compatibility of basic types as assignBasic does with values.
+func (a *Assigner) canAssignBasic(dt, st reflect.Type) error {
+	if _, ok := bigSet[st]; ok {
+		return nil
+	}
+	if _, ok := a.enums.values[dt]; ok && st.Kind() == reflect.String {
+		return nil
+	}
+	if _, ok := a.enums.names[st]; ok && dt.Kind() == reflect.String {
+		return nil
+	}
+	if st == jsonNumberType {
+		return nil
+	}
+	if dt == durationType && st.Kind() == reflect.String && a.durations {
+		return nil
+	}
+	if dt.Kind() == reflect.String && a.base64 && isBytes(st) {
+		return nil
+	}
+	if !st.ConvertibleTo(dt) {
+		return newError(dt, st.Kind())
+	}
+	return nil
+}
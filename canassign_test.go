@@ -0,0 +1,87 @@
+package assign
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCanAssign(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		dst     reflect.Type
+		src     reflect.Type
+		options []Option
+		valid   bool
+	}{
+		{
+			name:  "all",
+			dst:   reflect.TypeOf(All{}),
+			src:   reflect.TypeOf(All{}),
+			valid: true,
+		},
+		{
+			name:  "pointer",
+			dst:   reflect.TypeOf(&All{}),
+			src:   reflect.TypeOf(&All{}),
+			valid: true,
+		},
+		{
+			name:  "subset",
+			dst:   reflect.TypeOf(UserDTO{}),
+			src:   reflect.TypeOf(UserModel{}),
+			valid: true,
+		},
+		{
+			name:  "incompatible field",
+			dst:   reflect.TypeOf(struct{ Field int }{}),
+			src:   reflect.TypeOf(struct{ Field []string }{}),
+			valid: false,
+		},
+		{
+			name:  "incompatible element",
+			dst:   reflect.TypeOf(map[string][]int{}),
+			src:   reflect.TypeOf(map[string][]Small{}),
+			valid: false,
+		},
+		{
+			name:  "map to struct",
+			dst:   reflect.TypeOf(Small{}),
+			src:   reflect.TypeOf(map[string]interface{}{}),
+			valid: false,
+		},
+		{
+			name:    "map to struct with option",
+			dst:     reflect.TypeOf(Small{}),
+			src:     reflect.TypeOf(map[string]interface{}{}),
+			options: []Option{WithMapToStruct()},
+			valid:   true,
+		},
+		{
+			name:  "recursive",
+			dst:   reflect.TypeOf(Node{}),
+			src:   reflect.TypeOf(Node{}),
+			valid: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := CanAssign(test.dst, test.src, test.options...)
+			if test.valid {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			expErr := ErrorType{}
+			if !errors.As(err, &expErr) {
+				t.Errorf("expected type: %T but found: %T", expErr, err)
+			}
+		})
+	}
+}
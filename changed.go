@@ -0,0 +1,133 @@
+package assign
+
+import "reflect"
+
+// originalElem dereferences the pointers and interfaces of the original value.
+func originalElem(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		v = v.Elem()
+	}
+	return v
+}
+
+// originalField provides the field of the original struct by name, or invalid when there is none.
+func originalField(v reflect.Value, name string) reflect.Value {
+	if v = originalElem(v); v.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	return v.FieldByName(name)
+}
+
+// originalIndex provides the element of the original list at the index, or invalid when there is none.
+func originalIndex(v reflect.Value, i int) reflect.Value {
+	if v = originalElem(v); v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return reflect.Value{}
+	}
+	return indexOrInvalid(v, i)
+}
+
+// originalKey provides the value of the original map by key, or invalid when there is none.
+func originalKey(v, key reflect.Value) reflect.Value {
+	if v = originalElem(v); v.Kind() != reflect.Map || !key.Type().AssignableTo(v.Type().Key()) {
+		return reflect.Value{}
+	}
+	return v.MapIndex(key)
+}
+
+// unchanged reports whether the value equals the original value at the current path
+// with the WithChangedOnly option.
+// Values without an original value are changed.
+func (a *Assigner) unchanged(cv reflect.Value, md *metadata) bool {
+	if !a.changed {
+		return false
+	}
+	ov := originalElem(md.orig)
+	if !ov.IsValid() || !ov.CanInterface() || ov.Type() != cv.Type() {
+		return false
+	}
+	return reflect.DeepEqual(ov.Interface(), cv.Interface())
+}
+
+// equal reports whether the composite source equals the destination with the WithSkipEqual option.
+// Only Go values of the same type are compared, addressable values are compared through their addresses
+// to avoid copying them into interfaces.
+// Generic maps and slices, e.g. from JSON, are compared to destinations of the same generic type.
+func (a *Assigner) equal(dv reflect.Value, sv Source) bool {
+	if _, ok := compositeSet[dv.Kind()]; !ok && dv.Kind() != reflect.Ptr {
+		return false
+	}
+	if !dv.CanInterface() {
+		return false
+	}
+	switch s := sv.(type) {
+	case *anySource:
+		return anyEqual(dv, s.val)
+	case *goSource:
+		if !s.val.IsValid() || s.val.Type() != dv.Type() || !s.val.CanInterface() {
+			return false
+		}
+		if dv.CanAddr() && s.val.CanAddr() {
+			return reflect.DeepEqual(dv.Addr().Interface(), s.val.Addr().Interface())
+		}
+		return reflect.DeepEqual(dv.Interface(), s.val.Interface())
+	}
+	return false
+}
+
+// anyEqual reports whether the destination of a generic map or slice deeply equals the generic value.
+// Addressable slices are compared through their addresses to avoid copying them into interfaces.
+func anyEqual(dv reflect.Value, val interface{}) bool {
+	switch val.(type) {
+	case map[string]interface{}:
+		if dv.Type() != stringMapType {
+			return false
+		}
+		return genericEqual(dv.Interface(), val)
+	case []interface{}:
+		if dv.Type() != anySliceType {
+			return false
+		}
+		if dv.CanAddr() {
+			return genericEqual(*dv.Addr().Interface().(*[]interface{}), val)
+		}
+		return genericEqual(dv.Interface(), val)
+	}
+	return false
+}
+
+// genericEqual reports whether the generic values are deeply equal as with reflect.DeepEqual.
+// Generic maps, slices and scalars are compared without reflection so they are not allocated.
+func genericEqual(x, y interface{}) bool {
+	switch x := x.(type) {
+	case map[string]interface{}:
+		y, ok := y.(map[string]interface{})
+		if !ok || len(x) != len(y) || (x == nil) != (y == nil) {
+			return false
+		}
+		for key, xv := range x {
+			if yv, ok := y[key]; !ok || !genericEqual(xv, yv) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		y, ok := y.([]interface{})
+		if !ok || len(x) != len(y) || (x == nil) != (y == nil) {
+			return false
+		}
+		for i := range x {
+			if !genericEqual(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	xt := reflect.TypeOf(x)
+	if xt != reflect.TypeOf(y) {
+		return false
+	}
+	if xt == nil || isKind(scalarSet, xt.Kind()) {
+		return x == y
+	}
+	return reflect.DeepEqual(x, y)
+}
@@ -0,0 +1,215 @@
+package assign
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAssignWithChangedOnly(t *testing.T) {
+	t.Parallel()
+
+	original := Config{
+		Name:   "service",
+		Port:   80,
+		Tags:   []string{"a", "b"},
+		Limits: map[string]int{"cpu": 1, "memory": 1},
+		Owner:  &Small{Field: "owner"},
+	}
+	src := Config{
+		Name:   "service",
+		Port:   8080,
+		Tags:   []string{"a", "c"},
+		Limits: map[string]int{"cpu": 1, "memory": 2},
+		Owner:  &Small{Field: "owner"},
+		Backup: &Small{Field: "backup"},
+	}
+	dst := Config{}
+	stats, err := From(src, WithChangedOnly(original)).ToStats(&dst)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	exp := Config{
+		Port:   8080,
+		Tags:   []string{"", "c"},
+		Limits: map[string]int{"cpu": 0, "memory": 2},
+		Owner:  &Small{},
+		Backup: &Small{Field: "backup"},
+	}
+	if diff := cmp.Diff(exp, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+	// The changed values and the keys of the map are assigned.
+	if stats.Assigned != 6 {
+		t.Errorf("expected assigned: %d but found: %d", 6, stats.Assigned)
+	}
+}
+
+func TestAssignWithChangedOnlyNoOriginal(t *testing.T) {
+	t.Parallel()
+
+	src := Config{Name: "service", Port: 80}
+	dst := Config{}
+	if err := ToFrom(&dst, src, WithChangedOnly(nil)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if diff := cmp.Diff(src, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+}
+
+func TestAssignWithSkipEqual(t *testing.T) {
+	t.Parallel()
+
+	newConfig := func() Config {
+		return Config{Name: "service", Port: 80, Tags: []string{"a"}, Limits: map[string]int{"cpu": 1}, Owner: &Small{Field: "owner"}}
+	}
+	src := newConfig()
+
+	t.Run("equal", func(t *testing.T) {
+		t.Parallel()
+		dst := newConfig()
+		owner := dst.Owner
+		stats, err := From(src, WithSkipEqual()).ToStats(&dst)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if stats.Assigned != 0 || stats.Skipped != 1 {
+			t.Errorf("expected assigned: %d and skipped: %d but found: %+v", 0, 1, stats)
+		}
+		if dst.Owner != owner {
+			t.Errorf("expected pointer: %p but found: %p", owner, dst.Owner)
+		}
+	})
+	t.Run("generic", func(t *testing.T) {
+		t.Parallel()
+		newTree := func() map[string]interface{} {
+			return map[string]interface{}{"name": "service", "tags": []interface{}{"a"}, "limits": map[string]interface{}{"cpu": 1.0}}
+		}
+		dst := newTree()
+		limits := dst["limits"]
+		stats, err := From(newTree(), WithSkipEqual()).ToStats(&dst)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if stats.Assigned != 0 || stats.Skipped != 1 {
+			t.Errorf("expected assigned: %d and skipped: %d but found: %+v", 0, 1, stats)
+		}
+		if reflect.ValueOf(dst["limits"]).Pointer() != reflect.ValueOf(limits).Pointer() {
+			t.Errorf("expected the equal map to be kept")
+		}
+	})
+	t.Run("generic field", func(t *testing.T) {
+		t.Parallel()
+		dst := struct {
+			Name string
+			Meta map[string]interface{}
+		}{Meta: map[string]interface{}{"tags": []interface{}{"a"}}}
+		src := map[string]interface{}{"Name": "service", "Meta": map[string]interface{}{"tags": []interface{}{"a"}}}
+		stats, err := From(src, WithSkipEqual(), WithMapToStruct()).ToStats(&dst)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if stats.Assigned != 1 || stats.Skipped != 1 {
+			t.Errorf("expected assigned: %d and skipped: %d but found: %+v", 1, 1, stats)
+		}
+	})
+	t.Run("not equal", func(t *testing.T) {
+		t.Parallel()
+		dst := newConfig()
+		dst.Owner.Field = "other"
+		dst.Port = 81
+		stats, err := From(src, WithSkipEqual()).ToStats(&dst)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if diff := cmp.Diff(src, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+		// Name, Port and Owner.Field are assigned while Tags and Limits are equal.
+		if stats.Assigned != 3 {
+			t.Errorf("expected assigned: %d but found: %d", 3, stats.Assigned)
+		}
+	})
+}
+
+// TestAssignWithSkipEqualAllocations is not parallel as AllocsPerRun must not run during parallel tests.
+// Go values are compared by reflect.DeepEqual which copies the values into interfaces and the entries of maps,
+// so skipping them allocates, though less than assigning.
+// Generic values are compared without allocations, so skipping them allocates only as assigning an empty struct.
+func TestAssignWithSkipEqualAllocations(t *testing.T) {
+	src := Config{Name: "service", Tags: []string{"a", "b"}, Limits: map[string]int{"cpu": 1, "memory": 2}}
+	dst := Config{Name: "service", Tags: []string{"a", "b"}, Limits: map[string]int{"cpu": 1, "memory": 2}}
+	skip := From(src, WithSkipEqual())
+	assigner := From(src)
+	skipped := testing.AllocsPerRun(10, func() {
+		_ = skip.To(&dst)
+	})
+	assigned := testing.AllocsPerRun(10, func() {
+		_ = assigner.To(&dst)
+	})
+	if skipped >= assigned {
+		t.Errorf("expected allocations: %v below: %v", skipped, assigned)
+	}
+
+	newTree := func() map[string]interface{} {
+		return map[string]interface{}{"name": "service", "tags": []interface{}{"a", "b"}, "limits": map[string]interface{}{"cpu": 1.0}}
+	}
+	tree, treeDst := newTree(), newTree()
+	skip = From(tree, WithSkipEqual())
+	skipped = testing.AllocsPerRun(10, func() {
+		_ = skip.To(&treeDst)
+	})
+	empty := From(struct{}{})
+	overhead := testing.AllocsPerRun(10, func() {
+		_ = empty.To(&struct{}{})
+	})
+	if skipped != overhead {
+		t.Errorf("expected allocations: %v but found: %v", overhead, skipped)
+	}
+	dv := reflect.ValueOf(&treeDst).Elem()
+	if compared := testing.AllocsPerRun(10, func() {
+		anyEqual(dv, tree)
+	}); compared != 0 {
+		t.Errorf("expected allocations: %v but found: %v", 0, compared)
+	}
+}
+
+func BenchmarkAssignWithSkipEqual(b *testing.B) {
+	src := Config{Name: "service", Port: 80, Tags: make([]string, 64), Limits: make(map[string]int, 64), Owner: &Small{Field: "owner"}}
+	for i := range src.Tags {
+		src.Tags[i] = strconv.Itoa(i)
+		src.Limits[strconv.Itoa(i)] = i
+	}
+	benchmarks := []struct {
+		name    string
+		options []Option
+	}{
+		{name: "skip equal", options: []Option{WithSkipEqual()}},
+		{name: "default"},
+	}
+	for _, bm := range benchmarks {
+		bm := bm
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			dst := Config{}
+			if err := ToFrom(&dst, src); err != nil {
+				b.Fatalf("unexpected error: %v", err)
+			}
+			assigner := From(src, bm.options...)
+			for i := 0; i < b.N; i++ {
+				if err := assigner.To(&dst); err != nil {
+					b.Fatalf("unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package assign
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// Change is a basic value of the destination that is changed by an assignment.
+type Change struct {
+	// Path is the destination path of the value, see WithInterfaceType for the format.
+	Path string
+	// Old is the value before the assignment, nil when the value did not exist.
+	Old interface{}
+	// New is the value after the assignment, nil when the value no longer exists.
+	New interface{}
+}
+
+// Diff provides the changes of assigning the source to the given Go value with options.
+// The Go value is not changed, the source is assigned to a deep copy of the Go value
+// which is then compared to the Go value.
+// Struct fields that are not exported are not compared.
+// Map keys are compared in the order of their formatted values.
+// See Assigner.To for additional details.
+func Diff(dst, src interface{}, options ...Option) ([]Change, error) {
+	dv := valueOf(dst)
+	if dv.Kind() != reflect.Ptr {
+		return nil, newError(dv.Type(), Of(src).Kind())
+	}
+	if dv.IsNil() {
+		return nil, newError(reflect.TypeOf(nil), Of(src).Kind())
+	}
+
+	old := dv.Elem()
+	cp := reflect.New(old.Type())
+	deepCopy(cp.Elem(), old, map[uintptr]reflect.Value{})
+	if err := From(src, options...).To(cp); err != nil {
+		return nil, err
+	}
+
+	d := &differ{seen: map[uintptr]struct{}{}}
+	d.diff("", old, cp.Elem())
+	return d.changes, nil
+}
+
+// deepCopy copies the source to the destination without sharing maps, slices or pointers.
+// Seen pointers are shared within the copy to preserve cyclical paths.
+// Values that cannot be interfaced are left as is.
+func deepCopy(dv, sv reflect.Value, seen map[uintptr]reflect.Value) {
+	if !dv.CanSet() || !sv.CanInterface() {
+		return
+	}
+	switch sv.Kind() {
+	case reflect.Ptr:
+		if sv.IsNil() {
+			return
+		}
+		if cp, ok := seen[sv.Pointer()]; ok {
+			dv.Set(cp)
+			return
+		}
+		cp := reflect.New(sv.Type().Elem())
+		seen[sv.Pointer()] = cp
+		deepCopy(cp.Elem(), sv.Elem(), seen)
+		dv.Set(cp)
+	case reflect.Struct:
+		dv.Set(sv)
+		n := sv.NumField()
+		for i := 0; i < n; i++ {
+			deepCopy(unexported(dv.Field(i)), readable(sv.Field(i)), seen)
+		}
+	case reflect.Map:
+		if sv.IsNil() {
+			return
+		}
+		if cp, ok := seen[sv.Pointer()]; ok {
+			dv.Set(cp)
+			return
+		}
+		cp := reflect.MakeMapWithSize(sv.Type(), sv.Len())
+		seen[sv.Pointer()] = cp
+		vt := sv.Type().Elem()
+		for mi := sv.MapRange(); mi.Next(); {
+			ev := reflect.New(vt).Elem()
+			deepCopy(ev, mi.Value(), seen)
+			cp.SetMapIndex(mi.Key(), ev)
+		}
+		dv.Set(cp)
+	case reflect.Slice:
+		if sv.IsNil() {
+			return
+		}
+		n := sv.Len()
+		cp := reflect.MakeSlice(sv.Type(), n, n)
+		for i := 0; i < n; i++ {
+			deepCopy(cp.Index(i), sv.Index(i), seen)
+		}
+		dv.Set(cp)
+	case reflect.Array:
+		n := sv.Len()
+		for i := 0; i < n; i++ {
+			deepCopy(dv.Index(i), sv.Index(i), seen)
+		}
+	case reflect.Interface:
+		if sv.IsNil() {
+			return
+		}
+		ev := sv.Elem()
+		cp := reflect.New(ev.Type()).Elem()
+		deepCopy(cp, ev, seen)
+		dv.Set(cp)
+	default:
+		dv.Set(sv)
+	}
+}
+
+// differ collects the changes between old and new values.
+type differ struct {
+	changes []Change
+	// seen tracks old pointers to prevent cyclical paths.
+	seen map[uintptr]struct{}
+}
+
+// diff compares the old and new values at the path.
+func (d *differ) diff(path string, ov, nv reflect.Value) {
+	if !ov.IsValid() || !nv.IsValid() {
+		d.change(path, ov, nv)
+		return
+	}
+	if reflect.DeepEqual(ov.Interface(), nv.Interface()) {
+		return
+	}
+
+	switch ov.Kind() {
+	case reflect.Ptr:
+		if !ov.IsNil() {
+			if _, ok := d.seen[ov.Pointer()]; ok {
+				return
+			}
+			d.seen[ov.Pointer()] = struct{}{}
+		}
+		d.diff(path, elemOrZero(ov), elemOrZero(nv))
+	case reflect.Struct:
+		// Structs without exported fields are basic values, e.g. time.Time.
+		t := ov.Type()
+		if !hasExported(t) {
+			d.change(path, ov, nv)
+			return
+		}
+		n := ov.NumField()
+		for i := 0; i < n; i++ {
+			if sf := t.Field(i); sf.PkgPath == "" {
+				d.diff(fieldPath(path, sf.Name), ov.Field(i), nv.Field(i))
+			}
+		}
+	case reflect.Map:
+		for _, key := range sortedKeys(ov, nv) {
+			d.diff(indexPath(path, fmt.Sprint(key.Interface())), ov.MapIndex(key), nv.MapIndex(key))
+		}
+	case reflect.Slice, reflect.Array:
+		n := ov.Len()
+		if nn := nv.Len(); nn > n {
+			n = nn
+		}
+		for i := 0; i < n; i++ {
+			d.diff(indexPath(path, strconv.Itoa(i)), indexOrInvalid(ov, i), indexOrInvalid(nv, i))
+		}
+	case reflect.Interface:
+		if ov.IsNil() || nv.IsNil() || ov.Elem().Type() != nv.Elem().Type() {
+			d.change(path, ov, nv)
+			return
+		}
+		d.diff(path, ov.Elem(), nv.Elem())
+	default:
+		d.change(path, ov, nv)
+	}
+}
+
+// change appends the change of old and new values at the path.
+func (d *differ) change(path string, ov, nv reflect.Value) {
+	c := Change{Path: path}
+	if ov.IsValid() {
+		c.Old = ov.Interface()
+	}
+	if nv.IsValid() {
+		c.New = nv.Interface()
+	}
+	d.changes = append(d.changes, c)
+}
+
+// hasExported reports whether the struct type has exported fields.
+func hasExported(t reflect.Type) bool {
+	n := t.NumField()
+	for i := 0; i < n; i++ {
+		if t.Field(i).PkgPath == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// elemOrZero provides the element of the pointer, or the zero element of a nil pointer.
+func elemOrZero(v reflect.Value) reflect.Value {
+	if v.IsNil() {
+		return reflect.Zero(v.Type().Elem())
+	}
+	return v.Elem()
+}
+
+// indexOrInvalid provides the element of the list at the index, or invalid when out of range.
+func indexOrInvalid(v reflect.Value, i int) reflect.Value {
+	if i >= v.Len() {
+		return reflect.Value{}
+	}
+	return v.Index(i)
+}
+
+// sortedKeys provides the union of the map keys sorted by their formatted values.
+func sortedKeys(ov, nv reflect.Value) []reflect.Value {
+	keys := map[interface{}]reflect.Value{}
+	for _, m := range []reflect.Value{ov, nv} {
+		for _, key := range m.MapKeys() {
+			keys[key.Interface()] = key
+		}
+	}
+	sorted := make([]reflect.Value, 0, len(keys))
+	for _, key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return fmt.Sprint(sorted[i].Interface()) < fmt.Sprint(sorted[j].Interface())
+	})
+	return sorted
+}
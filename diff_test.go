@@ -0,0 +1,118 @@
+package assign
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	dst := Config{
+		Name:   "service",
+		Port:   80,
+		Tags:   []string{"a"},
+		Limits: map[string]int{"cpu": 1},
+		Owner:  &Small{Field: "owner"},
+	}
+	src := Config{
+		Port:   8080,
+		Tags:   []string{"b"},
+		Limits: map[string]int{"cpu": 1, "memory": 2},
+		Owner:  &Small{Field: "another"},
+		Backup: &Small{Field: "backup"},
+	}
+	exp := []Change{
+		{Path: "Port", Old: 80, New: 8080},
+		{Path: "Tags[0]", Old: "a", New: "b"},
+		{Path: "Limits[memory]", New: 2},
+		{Path: "Owner.Field", Old: "owner", New: "another"},
+		{Path: "Backup.Field", Old: "", New: "backup"},
+	}
+	before := Config{}
+	deepCopy(valueOf(&before).Elem(), valueOf(dst), map[uintptr]reflect.Value{})
+
+	changes, err := Diff(&dst, src)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	if diff := cmp.Diff(exp, changes); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, changes)
+	}
+	if diff := cmp.Diff(before, dst); diff != "" {
+		t.Errorf("unexpected change of destination (-expected +actual):\n%s\n%+v", diff, dst)
+	}
+}
+
+func TestDiffTime(t *testing.T) {
+	t.Parallel()
+
+	old := time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)
+	dst := Schedule{Start: old}
+	changes, err := Diff(&dst, ScheduleSource{Start: "2021-03-05T00:00:00Z"})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	exp := []Change{{Path: "Start", Old: old, New: old.AddDate(0, 0, 1)}}
+	if diff := cmp.Diff(exp, changes); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, changes)
+	}
+}
+
+func TestDiffNoChanges(t *testing.T) {
+	t.Parallel()
+
+	dst := allValue
+	changes, err := Diff(&dst, allValue)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if len(changes) != 0 {
+		t.Errorf("expected no changes but found: %+v", changes)
+	}
+}
+
+func TestDiffCycle(t *testing.T) {
+	t.Parallel()
+
+	dst := newCycle()
+	changes, err := Diff(&dst, Cycle{}, WithoutCycle())
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if len(changes) != 0 {
+		t.Errorf("expected no changes but found: %+v", changes)
+	}
+}
+
+func TestDiffError(t *testing.T) {
+	t.Parallel()
+
+	dst := Config{}
+	src := map[string]int{}
+	expErr := ErrorType{}
+	if _, err := Diff(&dst, src); !errors.As(err, &expErr) {
+		t.Errorf("expected type: %T but found: %T", expErr, err)
+	}
+	if _, err := Diff(dst, src); !errors.As(err, &expErr) {
+		t.Errorf("expected type: %T but found: %T", expErr, err)
+	}
+}
+
+type Config struct {
+	Name   string
+	Port   int
+	Tags   []string
+	Limits map[string]int
+	Owner  *Small
+	Backup *Small
+}
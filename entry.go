@@ -0,0 +1,97 @@
+package assign
+
+import "reflect"
+
+// entryList satisfies Source for a map assigned to a list of its entries, see assignChan.
+// The entries are in the order of the map iterator.
+type entryList struct {
+	Source
+	entries []Source
+}
+
+// newEntryList creates a new entryList from a map Source.
+func newEntryList(sm Source) *entryList {
+	el := &entryList{Source: sm, entries: make([]Source, 0, sm.Len())}
+	for mi := sm.MapRange(); mi.Next(); {
+		el.entries = append(el.entries, &entry{key: mi.Key(), value: mi.Value()})
+	}
+	return el
+}
+
+func (v *entryList) Kind() reflect.Kind {
+	return reflect.Slice
+}
+
+func (v *entryList) Len() int {
+	return len(v.entries)
+}
+
+func (v *entryList) Index(i int) Source {
+	return v.entries[i]
+}
+
+// entry satisfies Source for a map entry as a struct with the fields Key and Value.
+type entry struct {
+	key, value Source
+}
+
+func (v *entry) Kind() reflect.Kind {
+	return reflect.Struct
+}
+
+// Elem is the entry itself since entries are not pointers or interfaces.
+func (v *entry) Elem() Source {
+	return v
+}
+
+// FieldByName provides the key or value of the entry, other names are invalid.
+func (v *entry) FieldByName(name string) Source {
+	switch name {
+	case "Key":
+		return v.key
+	case "Value":
+		return v.value
+	}
+	return Of(nil)
+}
+
+// Fields provides the names of the key and value of the entry.
+func (v *entry) Fields() []string {
+	return []string{"Key", "Value"}
+}
+
+// Len is zero since entries are not lists.
+func (v *entry) Len() int {
+	return 0
+}
+
+// Index is invalid since entries are not lists.
+func (v *entry) Index(int) Source {
+	return Of(nil)
+}
+
+// Pointer is zero since entries are made per assignment.
+func (v *entry) Pointer() uintptr {
+	return 0
+}
+
+// MapRange provides an iterator of the key and value of the entry by name.
+func (v *entry) MapRange() MapIter {
+	return Of(v.Interface()).MapRange()
+}
+
+// Skip is false since the entry exists even when its key or value is zero.
+func (v *entry) Skip() bool {
+	return false
+}
+
+// Interface provides the key and value of the entry by name.
+func (v *entry) Interface() interface{} {
+	return map[string]interface{}{"Key": v.key.Interface(), "Value": v.value.Interface()}
+}
+
+var (
+	_ Source  = (*entryList)(nil)
+	_ Source  = (*entry)(nil)
+	_ Fielder = (*entry)(nil)
+)
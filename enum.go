@@ -0,0 +1,53 @@
+package assign
+
+import (
+	"reflect"
+)
+
+// enums are the registered enum types with names mapped to values and values mapped to names.
+type enums struct {
+	values map[reflect.Type]map[string]int
+	names  map[reflect.Type]map[int64]string
+}
+
+// register registers the enum type with names mapped to values.
+func (e *enums) register(typ reflect.Type, values map[string]int) {
+	if e.values == nil {
+		e.values = map[reflect.Type]map[string]int{}
+		e.names = map[reflect.Type]map[int64]string{}
+	}
+	if e.values[typ] == nil {
+		e.values[typ] = map[string]int{}
+		e.names[typ] = map[int64]string{}
+	}
+	for name, value := range values {
+		e.values[typ][name] = value
+		e.names[typ][int64(value)] = name
+	}
+}
+
+// assignEnum assigns a string to a registered enum type or a registered enum type to a string.
+// The bool result reports whether the enum handled the assignment.
+func (a *Assigner) assignEnum(db, sv reflect.Value, md *metadata) (bool, error) {
+	dt := db.Type()
+	if values, ok := a.enums.values[dt]; ok && sv.Kind() == reflect.String {
+		value, ok := values[sv.String()]
+		if !ok {
+			return true, newErrorEnum(dt, sv.Interface())
+		}
+		db.Set(reflect.ValueOf(value).Convert(dt))
+		md.stats.Assigned++
+		return true, nil
+	}
+	st := sv.Type()
+	if names, ok := a.enums.names[st]; ok && db.Kind() == reflect.String {
+		name, ok := names[reflect.ValueOf(sv.Interface()).Convert(int64Type).Int()]
+		if !ok {
+			return true, newErrorEnum(st, sv.Interface())
+		}
+		db.Set(reflect.ValueOf(name).Convert(dt))
+		md.stats.Assigned++
+		return true, nil
+	}
+	return false, nil
+}
@@ -1,8 +1,10 @@
 package assign
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 )
 
 // ErrorType handles the invalid assign of types case.
@@ -26,6 +28,302 @@ func (e ErrorType) Error() string {
 	return fmt.Sprintf("failed to assign to type: %v from source kind: %v", e.Dst, e.Src)
 }
 
+// ErrorOverflow handles the case of a value that overflows the type.
+type ErrorOverflow struct {
+	// Dst is the reflection type of the Go value.
+	Dst reflect.Type
+	// Src is the value of the source that overflows.
+	Src interface{}
+}
+
+// newErrorOverflow creates a new ErrorOverflow.
+func newErrorOverflow(dst reflect.Type, src interface{}) ErrorOverflow {
+	return ErrorOverflow{
+		Dst: dst,
+		Src: src,
+	}
+}
+
+func (e ErrorOverflow) Error() string {
+	return fmt.Sprintf("failed to assign to type: %v from overflowing source value: %v", e.Dst, e.Src)
+}
+
+// ErrorEnum handles the case of an enum value that is not registered.
+type ErrorEnum struct {
+	// Type is the reflection type of the enum.
+	Type reflect.Type
+	// Value is the name or value that is not registered.
+	Value interface{}
+}
+
+// newErrorEnum creates a new ErrorEnum.
+func newErrorEnum(typ reflect.Type, value interface{}) ErrorEnum {
+	return ErrorEnum{
+		Type:  typ,
+		Value: value,
+	}
+}
+
+func (e ErrorEnum) Error() string {
+	return fmt.Sprintf("unknown enum value: %v of type: %v", e.Value, e.Type)
+}
+
+// ErrorParse handles the case of a source string that fails to parse.
+type ErrorParse struct {
+	// Dst is the reflection type of the Go value.
+	Dst reflect.Type
+	// Src is the source string.
+	Src string
+	// Err is the error of parsing.
+	Err error
+}
+
+// newErrorParse creates a new ErrorParse.
+func newErrorParse(dst reflect.Type, src string, err error) ErrorParse {
+	return ErrorParse{
+		Dst: dst,
+		Src: src,
+		Err: err,
+	}
+}
+
+func (e ErrorParse) Error() string {
+	return fmt.Sprintf("failed to parse type: %v from source: %q: %v", e.Dst, e.Src, e.Err)
+}
+
+func (e ErrorParse) Unwrap() error {
+	return e.Err
+}
+
+// ErrorConvert handles the case of a converter that fails to convert the source.
+type ErrorConvert struct {
+	// Dst is the reflection type of the Go value.
+	Dst reflect.Type
+	// Src is the value of the source.
+	Src interface{}
+	// Err is the error of the converter.
+	Err error
+}
+
+// newErrorConvert creates a new ErrorConvert.
+func newErrorConvert(dst reflect.Type, src interface{}, err error) ErrorConvert {
+	return ErrorConvert{
+		Dst: dst,
+		Src: src,
+		Err: err,
+	}
+}
+
+func (e ErrorConvert) Error() string {
+	return fmt.Sprintf("failed to convert to type: %v from source value: %v with error: %v", e.Dst, e.Src, e.Err)
+}
+
+func (e ErrorConvert) Unwrap() error {
+	return e.Err
+}
+
+// ErrorSource handles the case of a source that fails to provide a value.
+type ErrorSource struct {
+	// Name is the name of the source field.
+	Name string
+	// Err is the error of the source.
+	Err error
+}
+
+// newErrorSource creates a new ErrorSource.
+func newErrorSource(name string, err error) ErrorSource {
+	return ErrorSource{
+		Name: name,
+		Err:  err,
+	}
+}
+
+func (e ErrorSource) Error() string {
+	return fmt.Sprintf("failed to get source field: %s: %v", e.Name, e.Err)
+}
+
+func (e ErrorSource) Unwrap() error {
+	return e.Err
+}
+
+// ErrorLimit handles the case of exceeding a limit.
+type ErrorLimit struct {
+	// Dst is the reflection type of the Go value.
+	Dst reflect.Type
+	// Limit is the limit that is exceeded.
+	Limit int
+}
+
+// newErrorLimit creates a new ErrorLimit.
+func newErrorLimit(dst reflect.Type, limit int) ErrorLimit {
+	return ErrorLimit{
+		Dst:   dst,
+		Limit: limit,
+	}
+}
+
+func (e ErrorLimit) Error() string {
+	return fmt.Sprintf("exceeded limit: %d while assigning to type: %v", e.Limit, e.Dst)
+}
+
+// ErrorUnhashableKey handles the case of a map key assigned with a value that is not hashable,
+// e.g. a slice assigned to a key of an interface type.
+type ErrorUnhashableKey struct {
+	// Dst is the reflection type of the map.
+	Dst reflect.Type
+	// Key is the reflection type of the assigned key.
+	Key reflect.Type
+}
+
+// newErrorUnhashableKey creates a new ErrorUnhashableKey.
+func newErrorUnhashableKey(dst, key reflect.Type) ErrorUnhashableKey {
+	return ErrorUnhashableKey{
+		Dst: dst,
+		Key: key,
+	}
+}
+
+func (e ErrorUnhashableKey) Error() string {
+	return fmt.Sprintf("unhashable key of type: %v while assigning to type: %v", e.Key, e.Dst)
+}
+
+// ErrorMissingField handles the case of a required field that is missing from the source.
+type ErrorMissingField struct {
+	// Dst is the reflection type of the struct.
+	Dst reflect.Type
+	// Name is the name of the field in the source.
+	Name string
+	// Path is the path of the field in the destination, e.g. Field.Slice[0].Name.
+	Path string
+}
+
+// newErrorMissingField creates a new ErrorMissingField.
+func newErrorMissingField(dst reflect.Type, name, path string) ErrorMissingField {
+	return ErrorMissingField{
+		Dst:  dst,
+		Name: name,
+		Path: path,
+	}
+}
+
+func (e ErrorMissingField) Error() string {
+	return fmt.Sprintf("missing required field: %s at path: %s of type: %v", e.Name, e.Path, e.Dst)
+}
+
+// ErrorPath handles the case of a destination path that is not found in the Go value, see WithPathMap.
+type ErrorPath struct {
+	// Dst is the reflection type of the Go value where the path is not found.
+	Dst reflect.Type
+	// Path is the JSON pointer of the destination, e.g. /user/name.
+	Path string
+}
+
+// newErrorPath creates a new ErrorPath.
+func newErrorPath(dst reflect.Type, path string) ErrorPath {
+	return ErrorPath{
+		Dst:  dst,
+		Path: path,
+	}
+}
+
+func (e ErrorPath) Error() string {
+	return fmt.Sprintf("failed to find path: %s in type: %v", e.Path, e.Dst)
+}
+
+// ErrorValidation handles the case of a field that violates a rule of its tag, e.g. `assign:"age,min=0"`.
+type ErrorValidation struct {
+	// Dst is the reflection type of the field.
+	Dst reflect.Type
+	// Path is the path of the field in the destination, e.g. Field.Slice[0].Name.
+	Path string
+	// Rule is the violated rule of the tag, e.g. min=0.
+	Rule string
+}
+
+// newErrorValidation creates a new ErrorValidation.
+func newErrorValidation(dst reflect.Type, path, rule string) ErrorValidation {
+	return ErrorValidation{
+		Dst:  dst,
+		Path: path,
+		Rule: rule,
+	}
+}
+
+func (e ErrorValidation) Error() string {
+	return fmt.Sprintf("failed to validate rule: %s at path: %s of type: %v", e.Rule, e.Path, e.Dst)
+}
+
+// ErrorUnmarshal handles the case of a Go value that fails to unmarshal the source.
+type ErrorUnmarshal struct {
+	// Dst is the reflection type of the Go value.
+	Dst reflect.Type
+	// Err is the error of the unmarshal.
+	Err error
+}
+
+// newErrorUnmarshal creates a new ErrorUnmarshal.
+func newErrorUnmarshal(dst reflect.Type, err error) ErrorUnmarshal {
+	return ErrorUnmarshal{
+		Dst: dst,
+		Err: err,
+	}
+}
+
+func (e ErrorUnmarshal) Error() string {
+	return fmt.Sprintf("failed to unmarshal to type: %v with error: %v", e.Dst, e.Err)
+}
+
+func (e ErrorUnmarshal) Unwrap() error {
+	return e.Err
+}
+
+// ErrorMultiple handles the case of errors collected with the WithErrorAggregation option.
+type ErrorMultiple struct {
+	// Errors are the collected errors in the order they occurred.
+	Errors []error
+	// Paths are the destination paths of the errors by index.
+	// The root destination has an empty path.
+	Paths []string
+	// Truncated is when further errors are dropped by the limit of the WithMaxErrors option.
+	Truncated bool
+}
+
+func (e ErrorMultiple) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (e ErrorMultiple) Unwrap() []error {
+	return e.Errors
+}
+
+// errorJSON is the JSON object of an error of ErrorMultiple.
+type errorJSON struct {
+	Path    string `json:"path"`
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// MarshalJSON encodes the errors as an array of objects with the path, type and message of each error,
+// e.g. [{"path":"Field","type":"ErrorType","message":"..."}].
+func (e ErrorMultiple) MarshalJSON() ([]byte, error) {
+	errs := make([]errorJSON, len(e.Errors))
+	for i, err := range e.Errors {
+		typ := reflect.TypeOf(err)
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		errs[i] = errorJSON{Type: typ.Name(), Message: err.Error()}
+		if i < len(e.Paths) {
+			errs[i].Path = e.Paths[i]
+		}
+	}
+	return json.Marshal(errs)
+}
+
 // ErrorCycle handles the cyclical paths case.
 type ErrorCycle struct {
 	Dst reflect.Type
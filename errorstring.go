@@ -0,0 +1,27 @@
+package assign
+
+import (
+	"errors"
+	"reflect"
+)
+
+// assignErrorString assigns between errors and strings, see WithErrorFromString.
+// Strings are assigned to error destinations with errors.New,
+// errors are assigned to string destinations with Error.
+// The handled result is false for other destinations and sources.
+func (a *Assigner) assignErrorString(dv reflect.Value, sv Source, md *metadata) (bool, error) {
+	switch {
+	case dv.Type() == errorType && sv.Kind() == reflect.String:
+		dv.Set(reflect.ValueOf(errors.New(a.valueOf(sv).String())))
+	case dv.Kind() == reflect.String && (sv.Kind() == reflect.Interface || sv.Kind() == reflect.Ptr):
+		err, ok := sv.Interface().(error)
+		if !ok {
+			return false, nil
+		}
+		dv.SetString(err.Error())
+	default:
+		return false, nil
+	}
+	md.stats.Assigned++
+	return true, nil
+}
@@ -0,0 +1,122 @@
+package assign
+
+import (
+	"flag"
+	"reflect"
+)
+
+// FlagSetSource provides a struct Source of the flags of the flag set by name,
+// e.g. the flag -port is assigned to a field named or tagged port.
+// The current values of the flags are read as they are assigned, e.g. after parsing the arguments.
+// Values of flag.Getter are provided as is, e.g. int or time.Duration, other values by their string.
+// The defined flags are enumerated in lexicographical order by Fields and MapRange.
+func FlagSetSource(fs *flag.FlagSet) Source {
+	return &flagSource{fs: fs}
+}
+
+// flagSource satisfies Source for a flag set.
+type flagSource struct {
+	fs *flag.FlagSet
+}
+
+func (v *flagSource) Kind() reflect.Kind {
+	return reflect.Struct
+}
+
+// Elem is the source itself since flag sets are not navigated as pointers.
+func (v *flagSource) Elem() Source {
+	return v
+}
+
+// FieldByName provides the current value of the flag by name.
+// The source is invalid when the flag is not defined.
+func (v *flagSource) FieldByName(name string) Source {
+	f := v.fs.Lookup(name)
+	if f == nil {
+		return Of(nil)
+	}
+	return flagValue(f)
+}
+
+// flagValue provides the value of the flag by flag.Getter, otherwise by its string.
+func flagValue(f *flag.Flag) Source {
+	if g, ok := f.Value.(flag.Getter); ok {
+		return Of(g.Get())
+	}
+	return Of(f.Value.String())
+}
+
+// Fields provides the names of the defined flags in lexicographical order.
+func (v *flagSource) Fields() []string {
+	var names []string
+	v.fs.VisitAll(func(f *flag.Flag) {
+		names = append(names, f.Name)
+	})
+	return names
+}
+
+func (v *flagSource) Len() int {
+	n := 0
+	v.fs.VisitAll(func(*flag.Flag) {
+		n++
+	})
+	return n
+}
+
+// Index is invalid since flag sets are not lists.
+func (v *flagSource) Index(int) Source {
+	return Of(nil)
+}
+
+// Pointer is always zero since flag sets have no cyclical paths.
+func (v *flagSource) Pointer() uintptr {
+	return 0
+}
+
+// MapRange provides an iterator of the defined flags in lexicographical order.
+func (v *flagSource) MapRange() MapIter {
+	it := &flagMapIter{i: -1}
+	v.fs.VisitAll(func(f *flag.Flag) {
+		it.flags = append(it.flags, f)
+	})
+	return it
+}
+
+// Skip handles nil flag sets.
+func (v *flagSource) Skip() bool {
+	return v.fs == nil
+}
+
+// Interface provides the current values of the defined flags by name.
+func (v *flagSource) Interface() interface{} {
+	m := map[string]interface{}{}
+	v.fs.VisitAll(func(f *flag.Flag) {
+		m[f.Name] = flagValue(f).Interface()
+	})
+	return m
+}
+
+// flagMapIter satisfies MapIter for the flags of a flag set.
+type flagMapIter struct {
+	flags []*flag.Flag
+	i     int
+}
+
+func (it *flagMapIter) Next() bool {
+	it.i++
+	return it.i < len(it.flags)
+}
+
+func (it *flagMapIter) Key() Source {
+	return Of(it.flags[it.i].Name)
+}
+
+func (it *flagMapIter) Value() Source {
+	return flagValue(it.flags[it.i])
+}
+
+var (
+	_ Source  = (*flagSource)(nil)
+	_ Fielder = (*flagSource)(nil)
+	_ MapIter = (*flagMapIter)(nil)
+)
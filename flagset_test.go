@@ -0,0 +1,87 @@
+package assign
+
+import (
+	"flag"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// listFlag is a flag.Value that is not a flag.Getter.
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
+
+func newFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.String("host", "localhost", "host to listen on")
+	fs.Int("port", 8080, "port to listen on")
+	fs.Bool("verbose", false, "verbose logging")
+	fs.Duration("timeout", time.Second, "request timeout")
+	fs.Var(&listFlag{}, "origin", "allowed origins")
+	return fs
+}
+
+func TestFlagSetSource(t *testing.T) {
+	t.Parallel()
+
+	fs := newFlagSet()
+	args := []string{"-port", "9090", "-verbose", "-timeout", "5s", "-origin", "a.example.com", "-origin", "b.example.com"}
+	if err := fs.Parse(args); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	dst := ServeConfig{}
+	if err := ToFrom(&dst, FlagSetSource(fs)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	expected := ServeConfig{
+		Host:    "localhost",
+		Port:    9090,
+		Verbose: true,
+		Timeout: 5 * time.Second,
+		Origins: "a.example.com,b.example.com",
+	}
+	if diff := cmp.Diff(expected, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+
+	t.Run("map", func(t *testing.T) {
+		t.Parallel()
+		var dst map[string]interface{}
+		if err := ToFrom(&dst, FlagSetSource(newFlagSet()), WithStructToMap()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		expected := map[string]interface{}{"host": "localhost", "port": 8080, "timeout": time.Second, "origin": nil, "verbose": nil}
+		if diff := cmp.Diff(expected, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+	t.Run("fields", func(t *testing.T) {
+		t.Parallel()
+		fields := FlagSetSource(newFlagSet()).(Fielder).Fields()
+		expected := []string{"host", "origin", "port", "timeout", "verbose"}
+		if diff := cmp.Diff(expected, fields); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, fields)
+		}
+	})
+}
+
+type ServeConfig struct {
+	Host    string        `assign:"host"`
+	Port    int           `assign:"port"`
+	Verbose bool          `assign:"verbose"`
+	Timeout time.Duration `assign:"timeout"`
+	Origins string        `assign:"origin"`
+}
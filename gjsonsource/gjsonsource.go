@@ -0,0 +1,150 @@
+// Package gjsonsource provides an assign.Source of gjson results.
+// Only the paths requested by the destination are navigated,
+// which avoids unmarshaling the entire JSON document.
+package gjsonsource
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/norunners/assign"
+	"github.com/tidwall/gjson"
+)
+
+// Of provides a Source from a gjson result.
+// JSON objects are maps, see assign.WithMapToStruct to assign objects to structs.
+// JSON arrays are slices.
+// JSON numbers are int64 when they are integers, otherwise float64.
+func Of(res gjson.Result) assign.Source {
+	return &source{res: res}
+}
+
+// Parse provides a Source from parsing the JSON.
+func Parse(json string) assign.Source {
+	return Of(gjson.Parse(json))
+}
+
+// source satisfies assign.Source for gjson results.
+type source struct {
+	res gjson.Result
+	// arr is the lazily parsed array.
+	arr []gjson.Result
+}
+
+func (v *source) Kind() reflect.Kind {
+	switch v.res.Type {
+	case gjson.False, gjson.True:
+		return reflect.Bool
+	case gjson.Number:
+		if v.isInt() {
+			return reflect.Int64
+		}
+		return reflect.Float64
+	case gjson.String:
+		return reflect.String
+	case gjson.JSON:
+		if v.res.IsArray() {
+			return reflect.Slice
+		}
+		return reflect.Map
+	}
+	return reflect.Invalid
+}
+
+// isInt reports whether the number is an integer that fits in an int64.
+func (v *source) isInt() bool {
+	_, err := strconv.ParseInt(v.res.Raw, 10, 64)
+	return err == nil
+}
+
+// Elem is the source itself since JSON has no pointers or interfaces.
+func (v *source) Elem() assign.Source {
+	return v
+}
+
+func (v *source) FieldByName(name string) assign.Source {
+	return Of(v.res.Get(gjson.Escape(name)))
+}
+
+func (v *source) Len() int {
+	if v.res.IsArray() {
+		return len(v.array())
+	}
+	n := 0
+	v.res.ForEach(func(_, _ gjson.Result) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+func (v *source) Index(i int) assign.Source {
+	return Of(v.array()[i])
+}
+
+// array provides the parsed array.
+func (v *source) array() []gjson.Result {
+	if v.arr == nil {
+		v.arr = v.res.Array()
+	}
+	return v.arr
+}
+
+// Pointer is always zero since JSON has no cyclical paths.
+func (v *source) Pointer() uintptr {
+	return 0
+}
+
+func (v *source) MapRange() assign.MapIter {
+	it := &mapIter{i: -1}
+	v.res.ForEach(func(key, val gjson.Result) bool {
+		it.keys = append(it.keys, key)
+		it.vals = append(it.vals, val)
+		return true
+	})
+	return it
+}
+
+// Skip handles values that do not exist, null and zero values.
+func (v *source) Skip() bool {
+	switch v.res.Type {
+	case gjson.Null, gjson.False:
+		return true
+	case gjson.Number:
+		return v.res.Num == 0
+	case gjson.String:
+		return v.res.Str == ""
+	}
+	return !v.res.Exists()
+}
+
+func (v *source) Interface() interface{} {
+	if v.res.Type == gjson.Number && v.isInt() {
+		return v.res.Int()
+	}
+	return v.res.Value()
+}
+
+var _ assign.Source = (*source)(nil)
+
+// mapIter satisfies assign.MapIter for JSON objects.
+type mapIter struct {
+	keys []gjson.Result
+	vals []gjson.Result
+	i    int
+}
+
+func (m *mapIter) Next() bool {
+	m.i++
+	return m.i < len(m.keys)
+}
+
+func (m *mapIter) Key() assign.Source {
+	return Of(m.keys[m.i])
+}
+
+func (m *mapIter) Value() assign.Source {
+	return Of(m.vals[m.i])
+}
+
+var _ assign.MapIter = (*mapIter)(nil)
@@ -0,0 +1,106 @@
+package gjsonsource
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/norunners/assign"
+)
+
+const order = `{
+	"id": 9007199254740993,
+	"customer": {"name": "Ada", "email": "ada@example.com"},
+	"items": [
+		{"sku": "a-1", "quantity": 2, "price": 1.5},
+		{"sku": "b-2", "quantity": 1, "price": 10}
+	],
+	"tags": {"gift": true, "rush": false},
+	"notes": null,
+	"ignored": {"deeply": {"nested": [1, 2, 3]}}
+}`
+
+type Order struct {
+	ID       int64    `json:"id"`
+	Customer Customer `json:"customer"`
+	Items    []Item   `json:"items"`
+	Tags     map[string]bool
+	Notes    string `json:"notes"`
+	Extra    interface{}
+}
+
+type Customer struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type Item struct {
+	SKU      string  `json:"sku"`
+	Quantity int     `json:"quantity"`
+	Price    float64 `json:"price"`
+}
+
+func TestSource(t *testing.T) {
+	t.Parallel()
+
+	dst := Order{Notes: "kept"}
+	src := Parse(order)
+	options := []assign.Option{
+		assign.WithTags("json"),
+		assign.WithMapToStruct(),
+	}
+	if err := assign.ToFrom(&dst, src, options...); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	exp := Order{
+		ID:       9007199254740993,
+		Customer: Customer{Name: "Ada", Email: "ada@example.com"},
+		Items: []Item{
+			{SKU: "a-1", Quantity: 2, Price: 1.5},
+			{SKU: "b-2", Quantity: 1, Price: 10},
+		},
+		Notes: "kept",
+	}
+	// The Tags field has no tag and the JSON key is lower case.
+	if diff := cmp.Diff(exp, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+}
+
+func TestSourceMap(t *testing.T) {
+	t.Parallel()
+
+	dst := map[string]bool{}
+	src := Parse(order).FieldByName("tags")
+	if err := assign.ToFrom(&dst, src); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	// False is a zero value that is skipped, which leaves the zero value in the map.
+	exp := map[string]bool{"gift": true, "rush": false}
+	if diff := cmp.Diff(exp, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+}
+
+func TestSourceInterface(t *testing.T) {
+	t.Parallel()
+
+	var dst interface{}
+	src := Parse(order).FieldByName("ignored")
+	if err := assign.ToFrom(&dst, src); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	exp := map[string]interface{}{
+		"deeply": map[string]interface{}{
+			"nested": []interface{}{1.0, 2.0, 3.0},
+		},
+	}
+	if diff := cmp.Diff(exp, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+}
@@ -0,0 +1,14 @@
+module github.com/norunners/assign/gjsonsource
+
+go 1.20
+
+require (
+	github.com/google/go-cmp v0.5.5
+	github.com/norunners/assign v0.0.0-20261016125326-23e6754a8e1f
+	github.com/tidwall/gjson v1.17.1
+)
+
+require (
+	github.com/tidwall/match v1.1.1 // indirect
+	github.com/tidwall/pretty v1.2.0 // indirect
+)
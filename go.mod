@@ -1,5 +1,5 @@
 module github.com/norunners/assign
 
-go 1.16
+go 1.20
 
-require github.com/google/go-cmp v0.5.5 // indirect
+require github.com/google/go-cmp v0.5.5
@@ -0,0 +1,100 @@
+package assign
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"strconv"
+)
+
+var jsonNumberType = reflect.TypeOf(json.Number(""))
+
+// assignJSONNumber assigns a json.Number to a numeric destination.
+// Integers are parsed without passing through float64 to keep their precision.
+// Numbers that fail to parse result in ErrorParse and numbers that overflow the destination result in ErrorOverflow.
+// The handled result is false for destinations that are not numeric.
+func (a *Assigner) assignJSONNumber(db, sv reflect.Value, md *metadata) (bool, error) {
+	dt := db.Type()
+	s := sv.String()
+	switch dk := dt.Kind(); {
+	case isKind(intSet, dk):
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return true, newErrorParse(dt, s, err)
+		}
+		if db.OverflowInt(n) {
+			return true, newErrorOverflow(dt, s)
+		}
+		db.SetInt(n)
+	case isKind(uintSet, dk):
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return true, newErrorParse(dt, s, err)
+		}
+		if db.OverflowUint(n) {
+			return true, newErrorOverflow(dt, s)
+		}
+		db.SetUint(n)
+	case dk == reflect.Float32 || dk == reflect.Float64:
+		f, err := strconv.ParseFloat(s, dt.Bits())
+		if err != nil {
+			return true, newErrorParse(dt, s, err)
+		}
+		db.SetFloat(f)
+	default:
+		return false, nil
+	}
+	md.stats.Assigned++
+	return true, nil
+}
+
+// isKind reports whether the kind is in the set.
+func isKind(set map[reflect.Kind]struct{}, kind reflect.Kind) bool {
+	_, ok := set[kind]
+	return ok
+}
+
+// assignFromBase64 assigns a base64 encoded string to a byte slice.
+// Strings that fail to decode result in ErrorParse.
+func (a *Assigner) assignFromBase64(ds reflect.Value, ss Source, md *metadata) error {
+	sv := a.valueOf(ss)
+	b, err := base64.StdEncoding.DecodeString(sv.String())
+	if err != nil {
+		return newErrorParse(ds.Type(), sv.String(), err)
+	}
+	ds.Set(reflect.ValueOf(b).Convert(ds.Type()))
+	md.stats.Assigned++
+	return nil
+}
+
+// isBytes reports whether the type is a slice of bytes.
+func isBytes(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
+var rawMessageType = reflect.TypeOf(json.RawMessage(nil))
+
+// assignRawMessage assigns the source to a json.RawMessage verbatim.
+// Byte slices are copied as is, other sources are encoded to JSON.
+// Invalid sources, e.g. nil values of maps, are encoded as null.
+// Sources that fail to encode result in ErrorConvert.
+func (a *Assigner) assignRawMessage(dr reflect.Value, sr Source, md *metadata) error {
+	if sr.Kind() == reflect.Invalid {
+		dr.SetBytes([]byte("null"))
+		md.stats.Assigned++
+		return nil
+	}
+	sv := a.valueOf(sr)
+	var b []byte
+	if isBytes(sv.Type()) {
+		b = append([]byte(nil), sv.Bytes()...)
+	} else {
+		var err error
+		if b, err = json.Marshal(sv.Interface()); err != nil {
+			return newErrorConvert(dr.Type(), sv.Interface(), err)
+		}
+	}
+	dr.SetBytes(b)
+	md.stats.Assigned++
+	return nil
+}
@@ -0,0 +1,187 @@
+package assign
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAssignJSONNumber(t *testing.T) {
+	t.Parallel()
+
+	t.Run("precise", func(t *testing.T) {
+		t.Parallel()
+		src := map[string]interface{}{}
+		dec := json.NewDecoder(strings.NewReader(`{"Int64": 9007199254740993, "Uint": 18446744073709551615, "Float": 1.5}`))
+		dec.UseNumber()
+		if err := dec.Decode(&src); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		dst := Numbers{}
+		if err := ToFrom(&dst, src, WithMapToStruct()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := Numbers{Int64: 9007199254740993, Uint: 18446744073709551615, Float: 1.5}
+		if diff := cmp.Diff(exp, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+	t.Run("string", func(t *testing.T) {
+		t.Parallel()
+		dst := ""
+		if err := ToFrom(&dst, json.Number("1.5")); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if dst != "1.5" {
+			t.Errorf("expected value: %q but found: %q", "1.5", dst)
+		}
+	})
+	t.Run("parse", func(t *testing.T) {
+		t.Parallel()
+		dst := int64(0)
+		expErr := ErrorParse{}
+		if err := ToFrom(&dst, json.Number("1.5")); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+	t.Run("overflow", func(t *testing.T) {
+		t.Parallel()
+		dst := int8(0)
+		expErr := ErrorOverflow{}
+		if err := ToFrom(&dst, json.Number("128")); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+}
+
+type Numbers struct {
+	Int64 int64
+	Uint  uint64
+	Float float64
+}
+
+func TestAssignWithByteSliceBase64(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+		src := "aGVsbG8="
+		var b []byte
+		if err := ToFrom(&b, src, WithByteSliceBase64()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if diff := cmp.Diff([]byte("hello"), b); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, b)
+		}
+		act := ""
+		if err := ToFrom(&act, b, WithByteSliceBase64()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if act != src {
+			t.Errorf("expected value: %q but found: %q", src, act)
+		}
+	})
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+		dst := ""
+		if err := ToFrom(&dst, []byte("hello")); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if dst != "hello" {
+			t.Errorf("expected value: %q but found: %q", "hello", dst)
+		}
+	})
+	t.Run("parse", func(t *testing.T) {
+		t.Parallel()
+		var dst []byte
+		expErr := ErrorParse{}
+		if err := ToFrom(&dst, "!", WithByteSliceBase64()); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+}
+
+func TestAssignWithRawMessagePassthrough(t *testing.T) {
+	t.Parallel()
+
+	src := map[string]interface{}{
+		"Kind": "event",
+		"Data": map[string]interface{}{"id": 1, "tags": []interface{}{"a", "b"}},
+		"Raw":  []byte(`{"verbatim": true}`),
+	}
+	dst := Document{}
+	if err := ToFrom(&dst, src, WithMapToStruct(), WithRawMessagePassthrough()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if diff := cmp.Diff(`{"id":1,"tags":["a","b"]}`, string(dst.Data)); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst.Data)
+	}
+	if diff := cmp.Diff(`{"verbatim": true}`, string(dst.Raw)); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst.Raw)
+	}
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		src := map[string]interface{}{"Data": func() {}}
+		dst := Document{}
+		expErr := ErrorConvert{}
+		if err := ToFrom(&dst, src, WithMapToStruct(), WithRawMessagePassthrough()); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+}
+
+func TestAssignRawMessageMap(t *testing.T) {
+	t.Parallel()
+
+	src := map[string]interface{}{
+		"object": map[string]interface{}{"id": 1, "tags": []interface{}{"a", "b"}},
+		"array":  []interface{}{1, "two", nil},
+		"string": "text",
+		"number": 1.5,
+		"zero":   0,
+		"false":  false,
+		"null":   nil,
+	}
+	var dst map[string]json.RawMessage
+	if err := ToFrom(&dst, src, WithRawMessagePassthrough()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	expected := map[string]interface{}{
+		"object": map[string]interface{}{"id": 1.0, "tags": []interface{}{"a", "b"}},
+		"array":  []interface{}{1.0, "two", nil},
+		"string": "text",
+		"number": 1.5,
+		"zero":   0.0,
+		"false":  false,
+		"null":   nil,
+	}
+	actual := map[string]interface{}{}
+	for key, raw := range dst {
+		var v interface{}
+		if err := json.Unmarshal(raw, &v); err != nil {
+			t.Errorf("unexpected error: %v of key: %q", err, key)
+			continue
+		}
+		actual[key] = v
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, actual)
+	}
+}
+
+type Document struct {
+	Kind string
+	Data json.RawMessage
+	Raw  json.RawMessage
+}
@@ -0,0 +1,187 @@
+package assign
+
+import (
+	"reflect"
+)
+
+// Merge provides a Source of the sources layered in the order given,
+// the values of later sources take precedence over the values of earlier sources.
+// Values that are skipped do not take precedence, e.g. zero values.
+// Structs and maps are merged by field name and key, while other values are taken as a whole.
+// This is useful for layered configuration, e.g. Merge(defaults, file, env, flags).
+func Merge(sources ...Source) Source {
+	srcs := make([]Source, len(sources))
+	for i, src := range sources {
+		srcs[len(sources)-1-i] = src
+	}
+	return newMergeSource(srcs)
+}
+
+// MergeFirst provides a Source of the sources layered in the order given,
+// the values of earlier sources take precedence over the values of later sources.
+// See Merge for additional details.
+func MergeFirst(sources ...Source) Source {
+	srcs := make([]Source, len(sources))
+	copy(srcs, sources)
+	return newMergeSource(srcs)
+}
+
+// mergeSource satisfies Source for merged sources.
+type mergeSource struct {
+	// srcs are the sources that are not skipped in the order of precedence.
+	// Pointers and interfaces are dereferenced.
+	srcs []Source
+}
+
+// newMergeSource creates a new mergeSource of the sources in the order of precedence.
+func newMergeSource(srcs []Source) *mergeSource {
+	ms := &mergeSource{srcs: make([]Source, 0, len(srcs))}
+	for _, src := range srcs {
+		if src = deref(src); !src.Skip() {
+			ms.srcs = append(ms.srcs, src)
+		}
+	}
+	return ms
+}
+
+// deref dereferences pointers and interfaces of the Source until skipped.
+func deref(src Source) Source {
+	for !src.Skip() {
+		if _, ok := elemSet[src.Kind()]; !ok {
+			break
+		}
+		src = src.Elem()
+	}
+	return src
+}
+
+// Kind is the kind of the source with the most precedence.
+func (v *mergeSource) Kind() reflect.Kind {
+	if len(v.srcs) == 0 {
+		return reflect.Invalid
+	}
+	return v.srcs[0].Kind()
+}
+
+func (v *mergeSource) Elem() Source {
+	return v
+}
+
+func (v *mergeSource) FieldByName(name string) Source {
+	fields := make([]Source, 0, len(v.srcs))
+	for _, src := range v.srcs {
+		switch src.Kind() {
+		case reflect.Struct:
+			fields = append(fields, src.FieldByName(name))
+		case reflect.Map:
+			fields = append(fields, newMapStruct(src, false).FieldByName(name))
+		}
+	}
+	return newMergeSource(fields)
+}
+
+// Fields is the union of the field names of the struct and map sources in the order of precedence,
+// each name is in the position of the first source that has it.
+// The names of each source are in the order of the source, see Fielder.
+func (v *mergeSource) Fields() []string {
+	var names []string
+	seen := map[string]struct{}{}
+	for _, src := range v.srcs {
+		if src.Kind() == reflect.Map {
+			src = newMapStruct(src, false)
+		}
+		fs, ok := src.(Fielder)
+		if !ok || src.Kind() != reflect.Struct {
+			continue
+		}
+		for _, name := range fs.Fields() {
+			if _, ok := seen[name]; !ok {
+				seen[name] = struct{}{}
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+func (v *mergeSource) Len() int {
+	if v.Kind() == reflect.Map {
+		n := 0
+		for mi := v.MapRange(); mi.Next(); {
+			n++
+		}
+		return n
+	}
+	return v.srcs[0].Len()
+}
+
+// Index is the element of the list with the most precedence, lists are not merged.
+func (v *mergeSource) Index(i int) Source {
+	return v.srcs[0].Index(i)
+}
+
+// Pointer is the pointer of the source with the most precedence.
+func (v *mergeSource) Pointer() uintptr {
+	return v.srcs[0].Pointer()
+}
+
+// MapRange is the union of the map keys in the order of precedence.
+func (v *mergeSource) MapRange() MapIter {
+	it := &mergeMapIter{i: -1}
+	index := map[interface{}]int{}
+	for _, src := range v.srcs {
+		if src.Kind() != reflect.Map {
+			continue
+		}
+		for mi := src.MapRange(); mi.Next(); {
+			key := mi.Key()
+			k := key.Interface()
+			i, ok := index[k]
+			if !ok {
+				i = len(it.keys)
+				index[k] = i
+				it.keys = append(it.keys, key)
+				it.vals = append(it.vals, nil)
+			}
+			it.vals[i] = append(it.vals[i], mi.Value())
+		}
+	}
+	return it
+}
+
+func (v *mergeSource) Skip() bool {
+	return len(v.srcs) == 0
+}
+
+// Interface is the value of the source with the most precedence.
+func (v *mergeSource) Interface() interface{} {
+	return v.srcs[0].Interface()
+}
+
+var (
+	_ Source  = (*mergeSource)(nil)
+	_ Fielder = (*mergeSource)(nil)
+)
+
+// mergeMapIter satisfies MapIter for merged maps.
+type mergeMapIter struct {
+	keys []Source
+	// vals are the values of each key in the order of precedence.
+	vals [][]Source
+	i    int
+}
+
+func (m *mergeMapIter) Next() bool {
+	m.i++
+	return m.i < len(m.keys)
+}
+
+func (m *mergeMapIter) Key() Source {
+	return m.keys[m.i]
+}
+
+func (m *mergeMapIter) Value() Source {
+	return newMergeSource(m.vals[m.i])
+}
+
+var _ MapIter = (*mergeMapIter)(nil)
@@ -0,0 +1,107 @@
+package assign
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	defaults := Settings{
+		Host:    "localhost",
+		Port:    80,
+		Debug:   false,
+		Limits:  map[string]int{"cpu": 1, "memory": 1},
+		Owner:   &Small{Field: "default"},
+		Servers: []string{"a", "b"},
+	}
+	file := map[string]interface{}{
+		"Host":   "example.com",
+		"Limits": map[string]int{"memory": 2},
+		"Owner":  map[string]interface{}{"Field": "file"},
+	}
+	env := &Settings{
+		Port:    8080,
+		Debug:   true,
+		Servers: []string{"c"},
+	}
+	exp := Settings{
+		Host:    "example.com",
+		Port:    8080,
+		Debug:   true,
+		Limits:  map[string]int{"cpu": 1, "memory": 2},
+		Owner:   &Small{Field: "file"},
+		Servers: []string{"c"},
+	}
+
+	dst := Settings{}
+	src := Merge(Of(defaults), Of(file), Of(env))
+	if err := ToFrom(&dst, src, WithMapToStruct()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if diff := cmp.Diff(exp, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+}
+
+func TestMergeFirst(t *testing.T) {
+	t.Parallel()
+
+	dst := Settings{}
+	src := MergeFirst(Of(Settings{Host: "first"}), Of(Settings{Host: "second", Port: 2}), Of(nil))
+	if err := ToFrom(&dst, src); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	exp := Settings{Host: "first", Port: 2}
+	if diff := cmp.Diff(exp, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+}
+
+func TestMergeEmpty(t *testing.T) {
+	t.Parallel()
+
+	dst := Settings{Host: "kept"}
+	if err := ToFrom(&dst, Merge()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	exp := Settings{Host: "kept"}
+	if diff := cmp.Diff(exp, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+}
+
+func TestMergeFields(t *testing.T) {
+	t.Parallel()
+
+	src := Merge(Of(map[string]interface{}{"Other": 1, "Field": "map"}), Of(Small{Field: "small"}), Of(nil))
+	fields := src.(Fielder).Fields()
+	exp := []string{"Field", "Other"}
+	if diff := cmp.Diff(exp, fields); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, fields)
+	}
+
+	pairs, err := ToOrderedPairs(src)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	expPairs := []Pair{{Key: "Field", Value: "small"}, {Key: "Other", Value: 1}}
+	if diff := cmp.Diff(expPairs, pairs); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, pairs)
+	}
+}
+
+type Settings struct {
+	Host    string
+	Port    int
+	Debug   bool
+	Limits  map[string]int
+	Owner   *Small
+	Servers []string
+}
@@ -1,5 +1,10 @@
 package assign
 
+import (
+	"reflect"
+	"time"
+)
+
 // Option follows the option pattern for Assigner.
 type Option func(*Assigner)
 
@@ -16,6 +21,22 @@ func WithTags(tags ...string) Option {
 	}
 }
 
+// WithJSONTags includes the `json` tag key, as with WithTags("json").
+// Tags follow the conventions of JSON, e.g. `json:"name,omitempty"` is named by the name before the comma
+// and a field tagged with `json:"-"` is ignored.
+// This is useful for Go structs that are already annotated for JSON.
+func WithJSONTags() Option {
+	return func(a *Assigner) {
+		a.jsonTags = true
+		for _, tag := range a.tags {
+			if tag == "json" {
+				return
+			}
+		}
+		a.tags = append(a.tags, "json")
+	}
+}
+
 // WithoutCycle disables the cyclical path check.
 // This is useful for Source types that do not have cycles.
 // Source.Pointer is not called and need not be behave as expected for this case.
@@ -26,3 +47,702 @@ func WithoutCycle() Option {
 		a.cycle = false
 	}
 }
+
+// WithMapIndexedLists enables assigning slices and arrays from maps keyed by index.
+// This is useful for sources that represent sparse lists as maps,
+// e.g. map[int]string{0: "a", 2: "c"} or map[string]string{"0": "a", "2": "c"}.
+// Map keys must be integers or strings of integers.
+// Each value is assigned at its index and the gaps are left as is.
+// A nil slice is made with the length of the largest index plus one,
+// which is bounded by the WithMaxElements option for untrusted sources.
+// Indices out of range of an array or a non-nil slice are skipped, see WithStrictIndices.
+func WithMapIndexedLists() Option {
+	return func(a *Assigner) {
+		a.mapIndexed = true
+	}
+}
+
+// WithInterfaceType assigns the concrete type to the interface at the destination path.
+// The value of the concrete type is allocated, assigned and then boxed into the interface.
+// This is useful for polymorphic sources, e.g. decoding a map into a *Event held by an interface{}.
+// The path is made of the Go field names of the destination joined by a dot,
+// with slice and array indices or map keys in brackets, e.g. Events[0].Payload.
+// The empty path is the root destination.
+// The concrete type must implement the interface type of the destination.
+func WithInterfaceType(path string, typ reflect.Type) Option {
+	return func(a *Assigner) {
+		if a.ifaceTypes == nil {
+			a.ifaceTypes = map[string]reflect.Type{}
+		}
+		a.ifaceTypes[path] = typ
+	}
+}
+
+// WithMapToStruct enables assigning structs from maps.
+// The map keys are matched to the names of the struct fields,
+// see WithTags for how field names are resolved.
+// Map keys that are not strings are ignored.
+// This is useful for generic sources, e.g. map[string]interface{} from JSON.
+func WithMapToStruct() Option {
+	return func(a *Assigner) {
+		a.mapStructs = true
+	}
+}
+
+// WithAppendSlices appends the source elements to destination slices that are not nil.
+// By default, the source elements are assigned from the first index of the destination.
+// This is useful for accumulating elements across multiple assignments to the same destination.
+// Arrays are not supported as their length is fixed, they are assigned as usual.
+func WithAppendSlices() Option {
+	return func(a *Assigner) {
+		a.appendList = true
+	}
+}
+
+// WithReuseSliceBuffer re-slices destination slices that are not nil to the source length.
+// The backing array is reused when its capacity allows, otherwise the slice grows by appending.
+// By default, only the elements within the length of both slices are assigned.
+// This is useful for reducing allocations when assigning to the same destination repeatedly.
+// Elements are zeroed before assigning, so values of earlier assignments are not kept
+// where the source values are skipped, e.g. zero values.
+func WithReuseSliceBuffer() Option {
+	return func(a *Assigner) {
+		a.reuseList = true
+	}
+}
+
+// WithRecursionGuard limits how many times a destination type may be nested in itself.
+// ErrorCycle is returned when the limit is exceeded.
+// Cyclical paths are checked by pointer, which does not detect sources
+// that recurse by value, e.g. a Source providing a fresh copy of itself as a field.
+// Such sources otherwise recurse without bound when the destination type is recursive.
+// The guard is keyed by the struct, map, slice and array types of the destination.
+// The limit is disabled by default and when not positive.
+func WithRecursionGuard(limit int) Option {
+	return func(a *Assigner) {
+		a.recursion = limit
+	}
+}
+
+// WithUnsafeUnexported assigns to struct fields that are not exported using package unsafe.
+// Fields of Go sources that are not exported are read as well.
+// This is useful for faithful deep copies of internal types.
+// WARNING: This is dangerous as it bypasses the visibility rules of Go.
+// Invariants maintained by the package of the type may be broken,
+// e.g. copying a sync.Mutex or internal pointers.
+// Only use this option for types that are controlled and well understood.
+// Fields that are not exported are ignored by default.
+func WithUnsafeUnexported() Option {
+	return func(a *Assigner) {
+		a.unexported = true
+	}
+}
+
+// WithEnum registers enum types with names mapped to values.
+// Strings are assigned to registered enum types by name
+// and registered enum types are assigned to strings by value.
+// Enum types must be integer types, e.g. type Status int.
+// ErrorEnum is returned when a name or value is not registered.
+func WithEnum(enums map[reflect.Type]map[string]int) Option {
+	return func(a *Assigner) {
+		for typ, values := range enums {
+			a.enums.register(typ, values)
+		}
+	}
+}
+
+// WithTimeLayouts appends layouts to parse time.Time from strings.
+// The layouts are tried in the order given, starting with time.RFC3339 being the default layout.
+// ErrorParse is returned when no layout matches.
+func WithTimeLayouts(layouts ...string) Option {
+	return func(a *Assigner) {
+		a.timeLayouts = append(a.timeLayouts, layouts...)
+	}
+}
+
+// WithUnixTime assigns time.Time from integers as Unix timestamps in the given unit,
+// e.g. time.Second or time.Millisecond.
+// Integers are not assigned to time.Time by default.
+func WithUnixTime(unit time.Duration) Option {
+	return func(a *Assigner) {
+		a.unixTime = unit
+	}
+}
+
+// WithMapValueType assigns the concrete type to interface values of maps with keys matching the pattern.
+// The value of the concrete type is allocated, assigned and then boxed into the interface,
+// see WithInterfaceType for assigning by destination path instead.
+// The pattern syntax is of path.Match, e.g. "user*" matches keys with the prefix user.
+// Keys are formatted as strings to match and the first matching pattern is used.
+// This is useful for heterogeneous maps, e.g. map[string]interface{} with typed values by key.
+func WithMapValueType(pattern string, typ reflect.Type) Option {
+	return func(a *Assigner) {
+		a.mapTypes = append(a.mapTypes, mapType{pattern: pattern, typ: typ})
+	}
+}
+
+// WithStringTransform transforms strings assigned to string destinations,
+// e.g. strings.TrimSpace or strings.ToLower.
+// The transform runs after the source is converted to the string destination.
+// Transforms are applied in the order given.
+// This is useful for normalizing dirty data during assignment.
+func WithStringTransform(fn func(string) string) Option {
+	return func(a *Assigner) {
+		a.strTrans = append(a.strTrans, fn)
+	}
+}
+
+// WithDottedKeys assigns structs from maps with dotted keys as nested fields,
+// e.g. the key "address.city" is assigned to the field City of the field Address.
+// The name before the first dot is the name of the field and the rest is the key within it.
+// Keys matching the name of a field directly take precedence over dotted keys.
+// This enables WithMapToStruct as well.
+func WithDottedKeys() Option {
+	return func(a *Assigner) {
+		a.mapStructs = true
+		a.dotted = true
+	}
+}
+
+// WithDiscriminator assigns concrete types to interfaces by the discriminator field of the source.
+// The value of the discriminator field is formatted as a string to look up the concrete type in the registry.
+// The value of the concrete type is allocated, assigned and then boxed into the interface.
+// Sources of maps and structs are discriminated, this enables WithMapToStruct as well.
+// Interfaces are assigned as usual when the discriminator field is absent or not registered.
+// This is useful for polymorphic sources, e.g. events with a "type" field.
+func WithDiscriminator(field string, registry map[string]reflect.Type) Option {
+	return func(a *Assigner) {
+		a.mapStructs = true
+		a.discField = field
+		if a.discTypes == nil {
+			a.discTypes = map[string]reflect.Type{}
+		}
+		for name, typ := range registry {
+			a.discTypes[name] = typ
+		}
+	}
+}
+
+// WithDurationParsing assigns time.Duration from strings parsed by time.ParseDuration, e.g. "1h30m".
+// ErrorParse is returned when the string is not a valid duration.
+// Integers are assigned as nanoseconds regardless of this option.
+func WithDurationParsing() Option {
+	return func(a *Assigner) {
+		a.durations = true
+	}
+}
+
+// WithPointerSharing assigns source pointers to destination pointers of the identical type.
+// By default, destination pointers are allocated and assigned as deep copies.
+// WARNING: The destination shares the values of the source pointers,
+// changes to the values through either the source or destination are visible to both.
+// Pointers of different types are allocated and assigned as usual.
+func WithPointerSharing() Option {
+	return func(a *Assigner) {
+		a.sharing = true
+	}
+}
+
+// WithGetters assigns struct fields from getter methods of Go sources
+// when the source struct has no field by the name.
+// Getters have no parameters and return a value, e.g. func (u User) FullName() string,
+// or a value and an error, e.g. func (u User) Age() (int, error).
+// ErrorSource wraps the error returned by a getter.
+// Getters with pointer receivers are called when the source struct is addressable,
+// e.g. when the source is a pointer.
+func WithGetters() Option {
+	return func(a *Assigner) {
+		a.getters = true
+	}
+}
+
+// WithMapFilter includes map entries for which the filter returns true.
+// The filter receives the destination key after it is assigned from the source key.
+// Excluded entries are not assigned and are left as is in the destination map.
+// Multiple filters must all include an entry.
+// This is useful for dropping entries while copying maps, e.g. keys with an underscore prefix.
+func WithMapFilter(filter func(key interface{}) bool) Option {
+	return func(a *Assigner) {
+		a.mapFilters = append(a.mapFilters, filter)
+	}
+}
+
+// WithChannelFill assigns channels by sending each element of slice and array sources.
+// Map sources send each entry as a struct with the fields Key and Value in the order of the map iterator.
+// A nil channel is made with a buffer of the source length.
+// Sends block until received when block is true,
+// otherwise ErrorLimit is returned when the buffer of the channel is full.
+// Channels are assigned from other sources as basic values.
+func WithChannelFill(block bool) Option {
+	return func(a *Assigner) {
+		a.chanFill = true
+		a.chanBlock = block
+	}
+}
+
+// WithErrorAggregation continues assigning the remaining values when a value fails to assign.
+// By default, the first error is returned.
+// The errors are returned as ErrorMultiple, see errors.Is and errors.As to inspect them.
+// A Go value is partially assigned with all values that do not fail.
+func WithErrorAggregation() Option {
+	return func(a *Assigner) {
+		a.aggregate = true
+	}
+}
+
+// WithFieldNameNormalizer matches struct fields by their normalized names,
+// e.g. strings.ToLower to match names regardless of case
+// or NormalizeName to match snake_case and kebab-case keys of maps to Go field names.
+// The normalizer is applied to both the destination name and the names of the source fields.
+// An exact match of the destination name is preferred over a normalized match.
+// Normalizers are applied in the order given.
+// Sources must implement Fielder to be matched by normalized names, which Go structs and maps do.
+func WithFieldNameNormalizer(fn func(string) string) Option {
+	return func(a *Assigner) {
+		a.normalizers = append(a.normalizers, fn)
+	}
+}
+
+// WithOverflowCheck returns ErrorOverflow for numbers that overflow the numeric destination.
+// By default, numbers are converted as in Go, e.g. int(-1) wraps to the maximum uint.
+// Integers are checked for both the width and the sign of the destination,
+// e.g. a negative int overflows a uint and a uint above the signed maximum overflows an int.
+func WithOverflowCheck() Option {
+	return func(a *Assigner) {
+		a.overflow = true
+	}
+}
+
+// WithRequiredTag returns ErrorMissingField for struct fields tagged as required, e.g. `assign:"name,required"`,
+// when the source has no value for the field, or a zero value which is otherwise skipped.
+// A default tag of the field satisfies required, e.g. `assign:"name,required,default=value"`.
+func WithRequiredTag() Option {
+	return func(a *Assigner) {
+		a.required = true
+	}
+}
+
+// WithInferInterfaceType assigns empty interfaces with a concrete type inferred from the kind of the source,
+// similar to decoding JSON into an interface{}.
+// Maps are assigned as map[string]interface{}, or map[interface{}]interface{} when the keys are not strings,
+// slices and arrays as []interface{} and basic values as the basic type of their kind, e.g. int or string.
+// Go structs are assigned as a copy of their type.
+// The elements of inferred maps and slices are inferred in turn.
+// By default, the source value is boxed into the interface as is.
+func WithInferInterfaceType() Option {
+	return func(a *Assigner) {
+		a.inferIface = true
+	}
+}
+
+// WithProgress reports the progress of assigning the elements of a root slice, array or map source.
+// The total is the length of the root source and assigned is the number of its elements assigned so far.
+// The progress is reported about every percent of the total and once the total is reached.
+// This is useful for reporting the progress of assigning large sources, e.g. in CLI tools.
+// Sources that are not slices, arrays or maps are not reported.
+func WithProgress(fn func(assigned, total int)) Option {
+	return func(a *Assigner) {
+		a.progress = fn
+	}
+}
+
+// WithChangedOnly assigns only the basic values that differ from the original value at the same path,
+// compared with reflect.DeepEqual.
+// The original is typically of the same type as the destination, e.g. the row before an update.
+// Unchanged values are skipped, see ToStats to count the changed values as assigned.
+// Values without an original value at the same path are changed.
+func WithChangedOnly(original interface{}) Option {
+	return func(a *Assigner) {
+		a.changed = true
+		a.original = valueOf(original)
+	}
+}
+
+// WithValueTransform rewrites basic values before they are assigned,
+// e.g. to redact secrets or clamp ranges by the destination path and type.
+// The path is the destination path, e.g. Field.Slice[0].Map[key], and src is the source value.
+// The returned value is converted to the destination type when handled,
+// otherwise the source value is assigned as usual.
+// Transforms are applied in the order given, the first to handle the value is used.
+func WithValueTransform(fn func(path string, dst reflect.Type, src interface{}) (interface{}, bool)) Option {
+	return func(a *Assigner) {
+		a.valueTrans = append(a.valueTrans, fn)
+	}
+}
+
+// WithByteSliceBase64 assigns byte slices from strings by decoding them as standard base64
+// and assigns strings from byte slices by encoding them, as is conventional in JSON.
+// By default, the bytes of strings are assigned as is.
+// Strings that fail to decode result in ErrorParse.
+func WithByteSliceBase64() Option {
+	return func(a *Assigner) {
+		a.base64 = true
+	}
+}
+
+// WithComposite computes the struct field at the destination path from the struct source,
+// e.g. combining separate date and time fields of the source into a time.Time.
+// The path is of the destination, see WithInterfaceType for the path syntax.
+// The returned value is assigned to the field as a source, its error is returned as ErrorSource.
+func WithComposite(path string, fn func(src Source) (interface{}, error)) Option {
+	return func(a *Assigner) {
+		if a.composites == nil {
+			a.composites = map[string]func(Source) (interface{}, error){}
+		}
+		a.composites[path] = fn
+	}
+}
+
+// WithInterfaceReuse assigns interfaces that are not nil to their concrete value,
+// which keeps the established type of the destination, e.g. an interface{} holding a *Small.
+// Pointers are assigned in place, other values are copied, assigned and boxed again.
+// By default, the source value is boxed into the interface as is, which replaces the concrete value.
+// This is useful for partial updates of polymorphic fields.
+func WithInterfaceReuse() Option {
+	return func(a *Assigner) {
+		a.ifaceReuse = true
+	}
+}
+
+// WithStructToMap enables assigning maps of string keys from structs.
+// The keys are the names of the struct fields, see WithTags for how field names are resolved.
+// Fields that are not exported or tagged `json:"-"` with the WithJSONTags option are omitted.
+// This is the counterpart of WithMapToStruct, e.g. producing a map[string]interface{} for serialization.
+func WithStructToMap() Option {
+	return func(a *Assigner) {
+		a.structMaps = true
+	}
+}
+
+// WithFlatten assigns maps from structs with the fields of nested structs flattened into keys
+// joined by the separator, e.g. address.city with the separator ".".
+// Pointers to structs are flattened as well, nil pointers have no keys.
+// Structs without exported fields are not flattened, e.g. time.Time.
+// This is the counterpart of WithDottedKeys and enables WithStructToMap.
+func WithFlatten(separator string) Option {
+	return func(a *Assigner) {
+		a.flatten = separator
+		a.structMaps = true
+	}
+}
+
+// WithBinaryUnmarshaler assigns byte slices to destinations that implement encoding.BinaryUnmarshaler
+// with a pointer receiver by calling UnmarshalBinary, e.g. a UUID type with a binary form.
+// The error of UnmarshalBinary is returned as ErrorUnmarshal.
+func WithBinaryUnmarshaler() Option {
+	return func(a *Assigner) {
+		a.binary = true
+	}
+}
+
+// WithConverterFor registers a converter for the destination type.
+// The converter receives the source value and returns the value to assign,
+// which must be convertible to the destination type.
+// The error of the converter is returned as ErrorConvert.
+// Converters are dispatched by the exact destination type, the last registered for a type is used.
+// This is useful for types that are not assigned by default, e.g. parsing a custom ID from a string.
+func WithConverterFor(dst reflect.Type, fn func(src interface{}) (interface{}, error)) Option {
+	return func(a *Assigner) {
+		if a.converters == nil {
+			a.converters = map[reflect.Type]func(interface{}) (interface{}, error){}
+		}
+		a.converters[dst] = fn
+	}
+}
+
+// WithZeroDestination sets the destination to its zero value before assigning.
+// By default, values of the destination are kept where the source is skipped,
+// e.g. zero source values or fields missing from the source.
+// This is useful for a clean assignment when reusing a destination across assignments.
+func WithZeroDestination() Option {
+	return func(a *Assigner) {
+		a.zeroDst = true
+	}
+}
+
+// WithCycleSkip skips cyclical sources instead of returning ErrorCycle.
+// The destination of a cyclical source is left as is and the assignment continues,
+// see Stats.Cycles for the destination paths of the skipped cycles.
+// This is useful for copying graphs where the cycles are reconnected afterwards.
+func WithCycleSkip() Option {
+	return func(a *Assigner) {
+		a.cycleSkip = true
+	}
+}
+
+// WithAllocator allocates the new elements of the destination,
+// e.g. the keys and values of maps, the values of pointers and the concrete values of interfaces.
+// The allocator must return a settable and addressable zero value of the type,
+// e.g. reflect.New(t).Elem() which is the default.
+// This is useful for drawing values from pools in allocation heavy workloads.
+func WithAllocator(fn func(t reflect.Type) reflect.Value) Option {
+	return func(a *Assigner) {
+		a.alloc = fn
+	}
+}
+
+// WithOmitEmpty omits the fields of zero values from maps assigned from structs, as with omitempty of JSON.
+// By default, the zero value of the map is set for fields of zero values.
+// Fields are omitted when the value assigned to the map is zero, e.g. a pointer to a zero struct.
+// This is useful for serializing sparse structs, see WithStructToMap.
+func WithOmitEmpty() Option {
+	return func(a *Assigner) {
+		a.omitEmpty = true
+	}
+}
+
+// WithWarnUnmatchedDestination calls the function with the destination path of each struct field
+// that has no matching field in the source, e.g. Address.City.
+// Unmatched fields are skipped as usual, which may hide mismatched field names.
+// Fields of zero values in the source are matched.
+func WithWarnUnmatchedDestination(fn func(name string)) Option {
+	return func(a *Assigner) {
+		a.unmatched = fn
+	}
+}
+
+// WithRawMessagePassthrough assigns json.RawMessage destinations verbatim.
+// Byte slices are copied as is and other sources are encoded to JSON,
+// e.g. a nested map is assigned as the JSON of the map.
+// Values of maps to json.RawMessage are always encoded, e.g. a nil value is assigned as null.
+// By default, json.RawMessage is assigned as any byte slice.
+// This is useful for preserving unstructured sub-documents.
+// Sources that fail to encode result in ErrorConvert.
+func WithRawMessagePassthrough() Option {
+	return func(a *Assigner) {
+		a.rawMessage = true
+	}
+}
+
+// WithMaxErrors limits the number of errors collected with the WithErrorAggregation option.
+// Exactly the limit of errors are collected, the assignment is aborted once a further error is dropped
+// which sets ErrorMultiple.Truncated and is counted by Stats.Truncated.
+// This is useful for bounding the memory of errors from large malformed sources.
+// The limit is disabled by default and when not positive.
+func WithMaxErrors(n int) Option {
+	return func(a *Assigner) {
+		a.maxErrors = n
+	}
+}
+
+// WithStructToSlice assigns slices and arrays from structs by the position of their fields and vice versa,
+// e.g. a struct of three fields to a []interface{} of three elements.
+// Fields are in the order of declaration, fields that are not exported or tagged `json:"-"` with the WithJSONTags option have no position.
+// This is useful for positional records, e.g. rows of CSV.
+func WithStructToSlice() Option {
+	return func(a *Assigner) {
+		a.structLists = true
+		a.listStructs = true
+	}
+}
+
+// WithOrderedMap assigns nested structs of an OrderedMap as an *OrderedMap to preserve the order of their fields.
+// By default, nested structs are assigned as their Go values.
+func WithOrderedMap() Option {
+	return func(a *Assigner) {
+		a.orderedMaps = true
+	}
+}
+
+// WithSourceTags appends the tag keys to name the fields of struct sources, in the order given.
+// Fields of the destination are matched to the fields of the source by their tag names,
+// otherwise by the Go names of the source fields.
+// This maps fields between structs with different tags, e.g. `db` to `json`.
+func WithSourceTags(tags ...string) Option {
+	return func(a *Assigner) {
+		a.srcTags = append(a.srcTags, tags...)
+	}
+}
+
+// WithOnlyTags replaces the tag keys to be used in struct assignment, including the default `assign` tag key.
+// The tag key is matched on the order given, see WithTags option to append tag keys instead.
+func WithOnlyTags(tags ...string) Option {
+	return func(a *Assigner) {
+		a.tags = append([]string(nil), tags...)
+	}
+}
+
+// WithIntegerStrict returns ErrorType for floats with a fractional part assigned to integers.
+// By default, floats are converted as in Go, e.g. float64(3.7) truncates to int(3).
+// Floats without a fractional part are assigned, e.g. float64(3) from decoded JSON.
+func WithIntegerStrict() Option {
+	return func(a *Assigner) {
+		a.intStrict = true
+	}
+}
+
+// WithScalarToSlice assigns scalars to slices and arrays as the only element, e.g. "a" to []string{"a"}.
+// This is useful for sources of one or many values, e.g. form data or XML.
+// Strings are still assigned to slices of runes by code point.
+func WithScalarToSlice() Option {
+	return func(a *Assigner) {
+		a.scalarList = true
+	}
+}
+
+// WithPointerPresence assigns source pointers to zero values as the zero value of the destination.
+// A source pointer indicates presence, e.g. for patches,
+// a nil pointer is not provided and is skipped while a pointer to zero sets the destination to zero.
+// By default, zero values are skipped whether or not they are pointed to.
+func WithPointerPresence() Option {
+	return func(a *Assigner) {
+		a.presence = true
+	}
+}
+
+// WithPositionalStruct assigns structs from slices and arrays by the position of their elements,
+// e.g. the element at index i to the field i in the order of declaration.
+// Fields that are not exported or tagged `json:"-"` with the WithJSONTags option have no position.
+// This is useful for positional records, e.g. rows of database results or tuples.
+// See WithStructToSlice option to assign structs to slices as well.
+func WithPositionalStruct() Option {
+	return func(a *Assigner) {
+		a.listStructs = true
+	}
+}
+
+// WithMaxElements limits the total number of elements assigned to maps, slices, arrays and channels,
+// ErrorLimit is returned once the limit is exceeded.
+// Maps are not preallocated beyond the limit and slices are not allocated when the source exceeds the limit.
+// This bounds the memory of assigning from untrusted sources, e.g. decoded requests.
+// The limit is disabled by default and when not positive.
+func WithMaxElements(n int) Option {
+	return func(a *Assigner) {
+		a.maxElements = n
+	}
+}
+
+// WithErrorFromString assigns strings to error destinations with errors.New and errors to strings with Error.
+// This is useful to replay events with errors, e.g. from logs.
+// By default, strings are not assigned to errors.
+func WithErrorFromString() Option {
+	return func(a *Assigner) {
+		a.errStrings = true
+	}
+}
+
+// WithFieldOrder sorts the fields of structs to assign with the less function, stable by the order of declaration.
+// By default, fields are assigned in the order of declaration.
+// This is useful when assigning a field has effects that others depend on, e.g. converters or transforms.
+func WithFieldOrder(less func(a, b reflect.StructField) bool) Option {
+	return func(a *Assigner) {
+		a.fieldOrder = less
+	}
+}
+
+// WithBitFlags registers the names of the bits of bitmasks, e.g. map[string]int{"read": 1 << 0, "write": 1 << 1}.
+// Integers are assigned to maps of string keys to bool values by expanding every name to whether its bits are set,
+// and maps of names to bools are assigned to integers by packing the bits of the names that are true.
+// ErrorEnum is returned when a name is not registered.
+func WithBitFlags(flags map[string]int) Option {
+	return func(a *Assigner) {
+		if a.bitFlags == nil {
+			a.bitFlags = make(map[string]int, len(flags))
+		}
+		for name, flag := range flags {
+			a.bitFlags[name] = flag
+		}
+	}
+}
+
+// WithTagTransform transforms the names of matched tags before they are used to look up source fields,
+// e.g. to strip a prefix of tags written for another system.
+// Transforms are applied in the order given, a transformed name that is empty is the field name.
+func WithTagTransform(fn func(string) string) Option {
+	return func(a *Assigner) {
+		a.tagTrans = append(a.tagTrans, fn)
+	}
+}
+
+// WithSkipEqual skips composite values of the source that are deeply equal to the destination,
+// e.g. structs, maps, slices, arrays and pointers of the same type, see reflect.DeepEqual.
+// Generic sources, e.g. map[string]interface{} from JSON, are compared to values of the same generic type.
+// Equal values are not descended into, which avoids allocations and spurious changes, e.g. for diffs.
+func WithSkipEqual() Option {
+	return func(a *Assigner) {
+		a.skipEqual = true
+	}
+}
+
+// WithMaxStringLength limits the length in bytes of strings assigned to string destinations,
+// ErrorLimit is returned when a string exceeds the limit.
+// This bounds the memory of assigning from untrusted sources, e.g. decoded requests.
+// The limit is disabled by default and when not positive, see WithStringTruncation.
+func WithMaxStringLength(n int) Option {
+	return func(a *Assigner) {
+		a.maxString = n
+	}
+}
+
+// WithStringTruncation truncates strings that exceed the limit of the WithMaxStringLength option.
+// Strings are truncated without splitting a multi-byte rune, which may be shorter than the limit.
+// Truncated strings are counted by Stats.Truncated.
+// By default, ErrorLimit is returned instead.
+func WithStringTruncation() Option {
+	return func(a *Assigner) {
+		a.truncString = true
+	}
+}
+
+// WithFailFast returns the first error when fail is true, which is the default.
+// Otherwise, the errors are collected as with the WithErrorAggregation option,
+// see WithMaxErrors to bound the errors collected.
+func WithFailFast(fail bool) Option {
+	return func(a *Assigner) {
+		a.aggregate = !fail
+	}
+}
+
+// WithPathMap assigns only the destination paths from the source paths, e.g. /user/name from /data/fullName.
+// Paths are JSON pointers, which navigate maps by key, structs by field and lists by index.
+// Destination fields are matched by tag name, nil pointers and maps are allocated along the path.
+// Source paths that are not found are skipped and destination paths that are not found result in ErrorPath.
+// By default, the entire source is assigned. This is useful for sparse updates, e.g. patches.
+func WithPathMap(paths map[string]string) Option {
+	return func(a *Assigner) {
+		a.pathMaps = pathMappings(paths)
+	}
+}
+
+// WithResultBuffer reuses the backing array of the buffer for nil slice destinations of the same type,
+// e.g. repeatedly assigning to a nil []T in a loop with a buffer of type *[]T.
+// The buffer grows as needed and is set to the result, its elements are zeroed before assigning.
+// The buffer is overwritten by each assignment, results must be copied to be kept beyond the next assignment.
+// The buffer must be a pointer to a slice, otherwise it is ignored.
+// By default, a new slice is allocated for nil slice destinations.
+func WithResultBuffer(buf interface{}) Option {
+	return func(a *Assigner) {
+		if bv := reflect.ValueOf(buf); bv.Kind() == reflect.Ptr && !bv.IsNil() && bv.Elem().Kind() == reflect.Slice {
+			a.resultBuf = bv.Elem()
+		}
+	}
+}
+
+// WithTimeLocation parses time.Time from strings without a zone in the location,
+// e.g. the layout "2006-01-02 15:04:05" in America/New_York.
+// Strings with a zone or offset are parsed in their own zone, see time.ParseInLocation.
+// By default, strings without a zone are parsed in UTC.
+func WithTimeLocation(loc *time.Location) Option {
+	return func(a *Assigner) {
+		a.timeLoc = loc
+	}
+}
+
+// WithStructTagCache caches the parsed tags of struct types by the type and tag keys,
+// the cache is shared by all assigners with the option, see ResetCache.
+// This is useful for creating many short-lived assigners of the same types, e.g. per request.
+// By default, the tags of struct fields are parsed as they are assigned.
+func WithStructTagCache() Option {
+	return func(a *Assigner) {
+		a.tagCache = true
+	}
+}
+
+// WithStrictIndices returns ErrorLimit for indices out of range of an array or a non-nil slice
+// assigned from a map keyed by index, see WithMapIndexedLists.
+// By default, indices out of range are skipped.
+func WithStrictIndices() Option {
+	return func(a *Assigner) {
+		a.strictIndices = true
+	}
+}
@@ -0,0 +1,148 @@
+package assign
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// OrderedMap is a map of string keys in the order of insertion.
+// Structs are assigned to an OrderedMap in the order of their fields, see fieldsOf.
+// This is useful for formats with ordered keys, e.g. YAML.
+// The zero value is an empty map ready to use.
+type OrderedMap struct {
+	pairs   []Pair
+	indices map[string]int
+}
+
+var (
+	orderedMapType    = reflect.TypeOf(OrderedMap{})
+	orderedMapPtrType = reflect.TypeOf(&OrderedMap{})
+)
+
+// Set sets the value of the key.
+// New keys are appended, existing keys keep their order.
+func (m *OrderedMap) Set(key string, value interface{}) {
+	if i, ok := m.indices[key]; ok {
+		m.pairs[i].Value = value
+		return
+	}
+	if m.indices == nil {
+		m.indices = map[string]int{}
+	}
+	m.indices[key] = len(m.pairs)
+	m.pairs = append(m.pairs, Pair{Key: key, Value: value})
+}
+
+// Get provides the value of the key and reports whether the key is present.
+func (m *OrderedMap) Get(key string) (interface{}, bool) {
+	i, ok := m.indices[key]
+	if !ok {
+		return nil, false
+	}
+	return m.pairs[i].Value, true
+}
+
+// Len is the number of keys.
+func (m *OrderedMap) Len() int {
+	return len(m.pairs)
+}
+
+// Keys provides the keys in order.
+func (m *OrderedMap) Keys() []string {
+	keys := make([]string, len(m.pairs))
+	for i, pair := range m.pairs {
+		keys[i] = pair.Key
+	}
+	return keys
+}
+
+// Pairs provides the keys and values in order.
+func (m *OrderedMap) Pairs() []Pair {
+	return append([]Pair(nil), m.pairs...)
+}
+
+// Range calls the function for each key and value in order until it returns false.
+func (m *OrderedMap) Range(fn func(key string, value interface{}) bool) {
+	for _, pair := range m.pairs {
+		if !fn(pair.Key, pair.Value) {
+			return
+		}
+	}
+}
+
+// MarshalJSON marshals the map as a JSON object with keys in order.
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, pair := range m.pairs {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(pair.Key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		val, err := json.Marshal(pair.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+var _ json.Marshaler = (*OrderedMap)(nil)
+
+// assignOrderedMap assigns to an OrderedMap from the fields of a struct in order.
+// Maps are assigned as structs with the WithMapToStruct option, in the sorted order of their keys.
+// Nested structs are assigned as an *OrderedMap with the WithOrderedMap option.
+func (a *Assigner) assignOrderedMap(dm reflect.Value, ss Source, md *metadata) error {
+	if ss.Kind() == reflect.Map && a.mapStructs {
+		ss = newMapStruct(ss, a.dotted)
+	}
+	fields, ok := a.fieldsOf(ss)
+	if !ok {
+		return newError(dm.Type(), ss.Kind())
+	}
+	m := dm.Addr().Interface().(*OrderedMap)
+
+	f := md.save()
+	defer md.restore(f)
+
+	for _, field := range fields {
+		if err := a.element(dm.Type(), md); err != nil {
+			return err
+		}
+		dv := a.newValue(interfaceType)
+		md.index(f, field.name)
+		var err error
+		if a.orderedMaps && isStruct(field.src) {
+			err = a.assignInterface(dv, field.src, orderedMapPtrType, md)
+		} else {
+			err = a.assign(dv, field.src, md)
+		}
+		if err != nil {
+			return err
+		}
+		m.Set(field.name, dv.Interface())
+	}
+	return nil
+}
+
+// isStruct reports whether the source is a struct, dereferencing pointers and interfaces.
+func isStruct(s Source) bool {
+	for {
+		if _, ok := elemSet[s.Kind()]; !ok {
+			break
+		}
+		if s.Skip() {
+			return false
+		}
+		s = s.Elem()
+	}
+	return s.Kind() == reflect.Struct
+}
@@ -0,0 +1,66 @@
+package assign
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAssignOrderedMap(t *testing.T) {
+	t.Parallel()
+
+	src := Ordered{Zeta: "z", Alpha: 1, Mid: &Ordered{Zeta: "nested", Alpha: 2}, Ignored: "ignored"}
+
+	t.Run("struct", func(t *testing.T) {
+		t.Parallel()
+		dst := OrderedMap{}
+		if err := ToFrom(&dst, src, WithJSONTags()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := []Pair{
+			{Key: "zeta", Value: "z"},
+			{Key: "alpha", Value: 1},
+			{Key: "mid", Value: Ordered{Zeta: "nested", Alpha: 2}},
+		}
+		if diff := cmp.Diff(exp, dst.Pairs()); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst.Pairs())
+		}
+	})
+	t.Run("nested", func(t *testing.T) {
+		t.Parallel()
+		dst := &OrderedMap{}
+		if err := ToFrom(&dst, src, WithOrderedMap(), WithJSONTags()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		act, err := json.Marshal(dst)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := `{"zeta":"z","alpha":1,"mid":{"zeta":"nested","alpha":2,"mid":null}}`
+		if string(act) != exp {
+			t.Errorf("expected: %s but found: %s", exp, act)
+		}
+	})
+	t.Run("map", func(t *testing.T) {
+		t.Parallel()
+		dst := OrderedMap{}
+		if err := ToFrom(&dst, map[string]interface{}{"b": 2, "a": 1}, WithMapToStruct()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if diff := cmp.Diff([]string{"a", "b"}, dst.Keys()); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst.Keys())
+		}
+	})
+}
+
+type Ordered struct {
+	Zeta    string   `assign:"zeta"`
+	Alpha   int      `assign:"alpha"`
+	Mid     *Ordered `assign:"mid"`
+	Ignored string   `json:"-"`
+}
@@ -0,0 +1,48 @@
+package assign
+
+import "reflect"
+
+// Pair is the key and value of a struct field, see ToOrderedPairs.
+type Pair struct {
+	Key   string
+	Value interface{}
+}
+
+// ToOrderedPairs provides the fields of a struct source as pairs in the order of declaration.
+// See Assigner.ToOrderedPairs for additional details.
+func ToOrderedPairs(src interface{}, options ...Option) ([]Pair, error) {
+	return From(src, options...).ToOrderedPairs()
+}
+
+var pairsType = reflect.TypeOf([]Pair(nil))
+
+// ToOrderedPairs provides the fields of the struct source as pairs in the order of declaration.
+// This is useful for stable serialization, e.g. snapshot tests, as the order of a map is not.
+// Keys are named by tags, see WithTags, and fields ignored by their tags or that are not exported are omitted.
+// Sources that are not Go structs provide their fields in the order of Fielder, e.g. the sorted keys of a map.
+// Pointers and interfaces to structs are dereferenced.
+func (a *Assigner) ToOrderedPairs() ([]Pair, error) {
+	ss := a.src
+	for {
+		if _, ok := elemSet[ss.Kind()]; !ok {
+			break
+		}
+		ss = ss.Elem()
+	}
+	if ss.Kind() == reflect.Map && a.mapStructs {
+		ss = newMapStruct(ss, a.dotted)
+	}
+	if sk := ss.Kind(); sk != reflect.Struct {
+		return nil, newError(pairsType, sk)
+	}
+
+	fields, ok := a.fieldsOf(ss)
+	if !ok {
+		return nil, newError(pairsType, ss.Kind())
+	}
+	pairs := make([]Pair, len(fields))
+	for i, field := range fields {
+		pairs[i] = Pair{Key: field.name, Value: field.src.Interface()}
+	}
+	return pairs, nil
+}
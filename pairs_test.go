@@ -0,0 +1,69 @@
+package assign
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestToOrderedPairs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		src     interface{}
+		options []Option
+		exp     []Pair
+	}{
+		{
+			name: "struct",
+			src:  Config{Name: "service", Port: 80},
+			exp: []Pair{
+				{Key: "Name", Value: "service"},
+				{Key: "Port", Value: 80},
+				{Key: "Tags", Value: []string(nil)},
+				{Key: "Limits", Value: map[string]int(nil)},
+				{Key: "Owner", Value: (*Small)(nil)},
+				{Key: "Backup", Value: (*Small)(nil)},
+			},
+		},
+		{
+			name:    "tags",
+			src:     &JSONUser{Name: "Ada", Password: "secret"},
+			options: []Option{WithJSONTags()},
+			exp: []Pair{
+				{Key: "name", Value: "Ada"},
+				{Key: "email", Value: ""},
+				{Key: "-", Value: ""},
+			},
+		},
+		{
+			name:    "map",
+			src:     map[string]interface{}{"b": 2, "a": 1},
+			options: []Option{WithMapToStruct()},
+			exp:     []Pair{{Key: "a", Value: 1}, {Key: "b", Value: 2}},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			act, err := ToOrderedPairs(test.src, test.options...)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if diff := cmp.Diff(test.exp, act); diff != "" {
+				t.Errorf("(-expected +actual):\n%s\n%+v", diff, act)
+			}
+		})
+	}
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		expErr := ErrorType{}
+		if _, err := ToOrderedPairs([]int{1}); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+}
@@ -0,0 +1,156 @@
+package assign
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// pathMapping is a destination path assigned from a source path, see WithPathMap.
+type pathMapping struct {
+	dst, src string
+}
+
+// pathMappings provides the mappings sorted by the destination paths.
+func pathMappings(paths map[string]string) []pathMapping {
+	pms := make([]pathMapping, 0, len(paths))
+	for dst, src := range paths {
+		pms = append(pms, pathMapping{dst: dst, src: src})
+	}
+	sort.Slice(pms, func(i, j int) bool {
+		return pms[i].dst < pms[j].dst
+	})
+	return pms
+}
+
+// pointerEscapes unescapes the reference tokens of JSON pointers.
+var pointerEscapes = strings.NewReplacer("~1", "/", "~0", "~")
+
+// pointerTokens splits the JSON pointer into its unescaped reference tokens,
+// e.g. /a~1b/c has the tokens a/b and c. The root pointer is empty and has no tokens.
+func pointerTokens(pointer string) []string {
+	if pointer == "" {
+		return nil
+	}
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, token := range tokens {
+		tokens[i] = pointerEscapes.Replace(token)
+	}
+	return tokens
+}
+
+// assignPaths assigns each destination path from its source path in the order of the destination paths.
+// Source paths that are not found are skipped and the destination is left untouched.
+func (a *Assigner) assignPaths(dv reflect.Value, md *metadata) error {
+	for _, pm := range a.pathMaps {
+		ss, ok := a.sourceAt(a.src, pointerTokens(pm.src))
+		if !ok {
+			md.stats.Skipped++
+			continue
+		}
+		if err := a.assignAt(dv, pointerTokens(pm.dst), pm.dst, ss, md); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sourceAt navigates the source by the tokens through maps by key, structs by field and lists by index.
+// The result is false when a token is not found.
+func (a *Assigner) sourceAt(s Source, tokens []string) (Source, bool) {
+	for _, token := range tokens {
+		for s.Kind() == reflect.Ptr || s.Kind() == reflect.Interface {
+			s = s.Elem()
+		}
+		switch s.Kind() {
+		case reflect.Map:
+			found := false
+			for mi := s.MapRange(); mi.Next(); {
+				if fmt.Sprint(mi.Key().Interface()) == token {
+					s, found = mi.Value(), true
+					break
+				}
+			}
+			if !found {
+				return nil, false
+			}
+		case reflect.Struct:
+			sf, err := a.fieldByName(s, token)
+			if err != nil {
+				return nil, false
+			}
+			s = sf
+		case reflect.Slice, reflect.Array:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= s.Len() {
+				return nil, false
+			}
+			s = s.Index(i)
+		default:
+			return nil, false
+		}
+	}
+	return s, s.Kind() != reflect.Invalid
+}
+
+// assignAt navigates the destination by the tokens and assigns the source at the end of the path.
+// Nil pointers and maps are allocated along the path, see WithAllocator, existing map values are kept.
+// Struct fields are matched by tag name, see tagOf.
+// A token that is not found results in ErrorPath.
+func (a *Assigner) assignAt(dv reflect.Value, tokens []string, pointer string, ss Source, md *metadata) error {
+	if len(tokens) == 0 {
+		return a.assign(dv, ss, md)
+	}
+	for dv.Kind() == reflect.Ptr {
+		if dv.IsNil() {
+			dv.Set(a.newValue(dv.Type().Elem()).Addr())
+		}
+		dv = dv.Elem()
+	}
+
+	f := md.save()
+	defer md.restore(f)
+
+	token := tokens[0]
+	dt := dv.Type()
+	switch dv.Kind() {
+	case reflect.Struct:
+		for i := 0; i < dt.NumField(); i++ {
+			sf := dt.Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			if tag := a.tagOf(sf); !tag.ignored && tag.name == token {
+				md.field(f, sf.Name)
+				return a.assignAt(dv.Field(i), tokens[1:], pointer, ss, md)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if i, err := strconv.Atoi(token); err == nil && i >= 0 && i < dv.Len() {
+			md.index(f, token)
+			return a.assignAt(dv.Index(i), tokens[1:], pointer, ss, md)
+		}
+	case reflect.Map:
+		if dt.Key().Kind() != reflect.String {
+			break
+		}
+		if dv.IsNil() {
+			dv.Set(reflect.MakeMap(dt))
+		}
+		dk := a.newValue(dt.Key())
+		dk.SetString(token)
+		ev := a.newValue(dt.Elem())
+		if cur := dv.MapIndex(dk); cur.IsValid() {
+			ev.Set(cur)
+		}
+		md.index(f, token)
+		if err := a.assignAt(ev, tokens[1:], pointer, ss, md); err != nil {
+			return err
+		}
+		dv.SetMapIndex(dk, ev)
+		return nil
+	}
+	return newErrorPath(dt, pointer)
+}
@@ -0,0 +1,109 @@
+package assign
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAssignWithPathMap(t *testing.T) {
+	t.Parallel()
+
+	src := map[string]interface{}{
+		"data": map[string]interface{}{
+			"fullName": "Ann Lee",
+			"emails":   []interface{}{"ann@example.com", "lee@example.com"},
+			"age":      41,
+		},
+	}
+	dst := Membership{
+		User:   Member{Name: "Ann", Email: "old@example.com", Age: 40},
+		Tags:   map[string]string{"tier": "gold"},
+		Active: true,
+	}
+	paths := map[string]string{
+		"/user/name":  "/data/fullName",
+		"/user/email": "/data/emails/1",
+		"/tags/a~1b":  "/data/fullName",
+		"/owner/name": "/data/missing",
+	}
+	if err := ToFrom(&dst, src, WithTags("json"), WithPathMap(paths)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	expected := Membership{
+		User:   Member{Name: "Ann Lee", Email: "lee@example.com", Age: 40},
+		Tags:   map[string]string{"tier": "gold", "a/b": "Ann Lee"},
+		Active: true,
+	}
+	if diff := cmp.Diff(expected, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+
+	t.Run("pointer", func(t *testing.T) {
+		t.Parallel()
+		dst := Membership{}
+		paths := map[string]string{"/owner/name": "/data/fullName"}
+		if err := ToFrom(&dst, src, WithTags("json"), WithPathMap(paths)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		expected := Membership{Owner: &Member{Name: "Ann Lee"}}
+		if diff := cmp.Diff(expected, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+	t.Run("allocator", func(t *testing.T) {
+		t.Parallel()
+		counts := map[reflect.Type]int{}
+		alloc := func(t reflect.Type) reflect.Value {
+			counts[t]++
+			return reflect.New(t).Elem()
+		}
+		dst := Membership{}
+		paths := map[string]string{"/owner/name": "/data/fullName", "/tags/name": "/data/fullName"}
+		if err := ToFrom(&dst, src, WithTags("json"), WithPathMap(paths), WithAllocator(alloc)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		expected := Membership{Owner: &Member{Name: "Ann Lee"}, Tags: map[string]string{"name": "Ann Lee"}}
+		if diff := cmp.Diff(expected, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+		exp := map[reflect.Type]int{
+			reflect.TypeOf(""):       2,
+			reflect.TypeOf(Member{}): 1,
+		}
+		if diff := cmp.Diff(exp, counts); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, counts)
+		}
+	})
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		dst := Membership{}
+		paths := map[string]string{"/user/phone": "/data/fullName"}
+		expErr := ErrorPath{}
+		if err := ToFrom(&dst, src, WithTags("json"), WithPathMap(paths)); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+			return
+		}
+		if expErr.Path != "/user/phone" {
+			t.Errorf("expected path: %q but found: %q", "/user/phone", expErr.Path)
+		}
+	})
+}
+
+type Membership struct {
+	User   Member            `json:"user"`
+	Owner  *Member           `json:"owner"`
+	Tags   map[string]string `json:"tags"`
+	Active bool              `json:"active"`
+}
+
+type Member struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Age   int    `json:"age"`
+}
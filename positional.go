@@ -0,0 +1,106 @@
+package assign
+
+import "reflect"
+
+// structList satisfies Source for a struct assigned to a list by the position of its fields.
+type structList struct {
+	Source
+	fields []namedSource
+}
+
+// newStructList creates a new structList from the fields of a struct Source, see fieldsOf.
+// The result is false when the fields of the source are not known.
+func (a *Assigner) newStructList(ss Source) (*structList, bool) {
+	fields, ok := a.fieldsOf(ss)
+	if !ok {
+		return nil, false
+	}
+	return &structList{Source: ss, fields: fields}, true
+}
+
+func (v *structList) Kind() reflect.Kind {
+	return reflect.Slice
+}
+
+func (v *structList) Len() int {
+	return len(v.fields)
+}
+
+func (v *structList) Index(i int) Source {
+	return v.fields[i].src
+}
+
+var _ Source = (*structList)(nil)
+
+// listStruct satisfies Source for a list assigned to a struct by the position of its elements.
+// The fields are named by the destination struct in the order of declaration.
+type listStruct struct {
+	Source
+	indices map[string]int
+}
+
+// newListStruct creates a new listStruct from a list Source for the struct type.
+// Fields that are not exported or ignored by their tags have no position, as with fieldsOf.
+func (a *Assigner) newListStruct(sl Source, dt reflect.Type) *listStruct {
+	n := dt.NumField()
+	ls := &listStruct{Source: sl, indices: make(map[string]int, n)}
+	for i := 0; i < n; i++ {
+		sf := dt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := a.tagOf(sf)
+		if tag.ignored {
+			continue
+		}
+		ls.indices[tag.name] = len(ls.indices)
+	}
+	return ls
+}
+
+func (v *listStruct) Kind() reflect.Kind {
+	return reflect.Struct
+}
+
+func (v *listStruct) FieldByName(name string) Source {
+	i, ok := v.indices[name]
+	if !ok || i >= v.Source.Len() {
+		return Of(nil)
+	}
+	return v.Source.Index(i)
+}
+
+var _ Source = (*listStruct)(nil)
+
+// scalarList satisfies Source for a scalar assigned to a list as the only element.
+type scalarList struct {
+	Source
+}
+
+// newScalarList creates a new scalarList when the source is a scalar, e.g. bool, int, string, etc.
+// The result is false for sources that are not scalars.
+func newScalarList(ss Source) (*scalarList, bool) {
+	if _, ok := scalarSet[ss.Kind()]; !ok {
+		return nil, false
+	}
+	return &scalarList{Source: ss}, true
+}
+
+func (v *scalarList) Kind() reflect.Kind {
+	return reflect.Slice
+}
+
+func (v *scalarList) Len() int {
+	return 1
+}
+
+func (v *scalarList) Index(int) Source {
+	return v.Source
+}
+
+// Pointer is zero as scalars have no pointer to track.
+func (v *scalarList) Pointer() uintptr {
+	return 0
+}
+
+var _ Source = (*scalarList)(nil)
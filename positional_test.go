@@ -0,0 +1,165 @@
+package assign
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAssignWithStructToSlice(t *testing.T) {
+	t.Parallel()
+
+	src := Row{Name: "Ada", Age: 36, Email: "ada@example.com", Active: true, internal: "internal"}
+	var row []interface{}
+	if err := ToFrom(&row, src, WithStructToSlice(), WithJSONTags()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	exp := []interface{}{"Ada", 36, true}
+	if diff := cmp.Diff(exp, row); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, row)
+	}
+
+	dst := Row{}
+	if err := ToFrom(&dst, row, WithStructToSlice(), WithJSONTags()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if diff := cmp.Diff(Row{Name: "Ada", Age: 36, Active: true}, dst, cmp.AllowUnexported(Row{})); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+
+	t.Run("array", func(t *testing.T) {
+		t.Parallel()
+		dst := [2]string{}
+		if err := ToFrom(&dst, Small{Field: "0"}, WithStructToSlice()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if diff := cmp.Diff([2]string{"0"}, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+}
+
+type Row struct {
+	Name     string
+	Age      int
+	Email    string `json:"-"`
+	Active   bool
+	internal string
+}
+
+func TestAssignWithScalarToSlice(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  interface{}
+		dst  interface{}
+		exp  interface{}
+	}{
+		{
+			name: "string",
+			src:  "a",
+			dst:  new([]string),
+			exp:  []string{"a"},
+		},
+		{
+			name: "converted",
+			src:  1,
+			dst:  new([]float64),
+			exp:  []float64{1},
+		},
+		{
+			name: "array",
+			src:  "a",
+			dst:  new([2]string),
+			exp:  [2]string{"a"},
+		},
+		{
+			name: "slice",
+			src:  []string{"a", "b"},
+			dst:  new([]string),
+			exp:  []string{"a", "b"},
+		},
+		{
+			name: "field",
+			src:  map[string]interface{}{"Tags": "a"},
+			dst:  new(Config),
+			exp:  Config{Tags: []string{"a"}},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			if err := ToFrom(test.dst, test.src, WithScalarToSlice(), WithMapToStruct()); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			act := reflect.ValueOf(test.dst).Elem().Interface()
+			if diff := cmp.Diff(test.exp, act); diff != "" {
+				t.Errorf("(-expected +actual):\n%s\n%+v", diff, act)
+			}
+		})
+	}
+}
+
+func TestAssignWithPositionalStruct(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  interface{}
+		exp  Row
+	}{
+		{
+			name: "same types",
+			src:  []interface{}{"Ada", 36, true},
+			exp:  Row{Name: "Ada", Age: 36, Active: true},
+		},
+		{
+			name: "converted",
+			src:  []interface{}{"Ada", 36.0, true},
+			exp:  Row{Name: "Ada", Age: 36, Active: true},
+		},
+		{
+			name: "shorter",
+			src:  []interface{}{"Ada"},
+			exp:  Row{Name: "Ada"},
+		},
+		{
+			name: "longer",
+			src:  [4]interface{}{"Ada", int8(36), true, "extra"},
+			exp:  Row{Name: "Ada", Age: 36, Active: true},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			act := Row{}
+			if err := ToFrom(&act, test.src, WithPositionalStruct(), WithJSONTags()); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if diff := cmp.Diff(test.exp, act, cmp.AllowUnexported(Row{})); diff != "" {
+				t.Errorf("(-expected +actual):\n%s\n%+v", diff, act)
+			}
+		})
+	}
+
+	t.Run("incompatible", func(t *testing.T) {
+		t.Parallel()
+		act := Row{}
+		expErr := ErrorType{}
+		if err := ToFrom(&act, []interface{}{"Ada", "36"}, WithPositionalStruct()); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+}
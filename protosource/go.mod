@@ -0,0 +1,9 @@
+module github.com/norunners/assign/protosource
+
+go 1.20
+
+require (
+	github.com/google/go-cmp v0.5.5
+	github.com/norunners/assign v0.0.0-20261016125326-23e6754a8e1f
+	google.golang.org/protobuf v1.33.0
+)
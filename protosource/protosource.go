@@ -0,0 +1,105 @@
+// Package protosource provides an assign.Source of protobuf messages.
+// Well-Known Types are provided as their Go equivalents,
+// e.g. *timestamppb.Timestamp as time.Time and *wrapperspb.StringValue as string.
+package protosource
+
+import (
+	"reflect"
+
+	"github.com/norunners/assign"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// Of provides a Source from a protobuf message or any Go value containing them.
+// Timestamps are time.Time, durations are time.Duration
+// and wrappers are the basic value they wrap.
+// Nil Well-Known Types are skipped like other nil pointers.
+func Of(v interface{}) assign.Source {
+	return of(assign.Of(v))
+}
+
+// of provides the Go equivalent of a Well-Known Type, otherwise wraps the source.
+func of(s assign.Source) assign.Source {
+	if v, ok := wellKnown(s); ok {
+		return assign.Of(v)
+	}
+	return &source{Source: s}
+}
+
+// wellKnown provides the Go equivalent of the source when it is a non-nil Well-Known Type.
+func wellKnown(s assign.Source) (interface{}, bool) {
+	if s.Kind() != reflect.Ptr || s.Skip() {
+		return nil, false
+	}
+	switch v := s.Interface().(type) {
+	case *timestamppb.Timestamp:
+		return v.AsTime(), true
+	case *durationpb.Duration:
+		return v.AsDuration(), true
+	case *wrapperspb.StringValue:
+		return v.GetValue(), true
+	case *wrapperspb.BoolValue:
+		return v.GetValue(), true
+	case *wrapperspb.Int32Value:
+		return v.GetValue(), true
+	case *wrapperspb.Int64Value:
+		return v.GetValue(), true
+	case *wrapperspb.UInt32Value:
+		return v.GetValue(), true
+	case *wrapperspb.UInt64Value:
+		return v.GetValue(), true
+	case *wrapperspb.FloatValue:
+		return v.GetValue(), true
+	case *wrapperspb.DoubleValue:
+		return v.GetValue(), true
+	case *wrapperspb.BytesValue:
+		return v.GetValue(), true
+	}
+	return nil, false
+}
+
+// source satisfies assign.Source by wrapping the sources it provides.
+type source struct {
+	assign.Source
+}
+
+func (v *source) Elem() assign.Source {
+	return of(v.Source.Elem())
+}
+
+func (v *source) FieldByName(name string) assign.Source {
+	return of(v.Source.FieldByName(name))
+}
+
+// Fields provides the names of the fields of the wrapped source when it is an assign.Fielder.
+func (v *source) Fields() []string {
+	if f, ok := v.Source.(assign.Fielder); ok {
+		return f.Fields()
+	}
+	return nil
+}
+
+func (v *source) Index(i int) assign.Source {
+	return of(v.Source.Index(i))
+}
+
+func (v *source) MapRange() assign.MapIter {
+	return &mapIter{MapIter: v.Source.MapRange()}
+}
+
+// mapIter satisfies assign.MapIter by wrapping the values it provides.
+type mapIter struct {
+	assign.MapIter
+}
+
+func (it *mapIter) Value() assign.Source {
+	return of(it.MapIter.Value())
+}
+
+var (
+	_ assign.Source  = (*source)(nil)
+	_ assign.Fielder = (*source)(nil)
+	_ assign.MapIter = (*mapIter)(nil)
+)
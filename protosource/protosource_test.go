@@ -0,0 +1,84 @@
+package protosource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/norunners/assign"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// EventMessage has the fields of a generated message with Well-Known Types.
+type EventMessage struct {
+	Name     *wrapperspb.StringValue
+	Time     *timestamppb.Timestamp
+	Timeout  *durationpb.Duration
+	Retries  *wrapperspb.Int32Value
+	Enabled  *wrapperspb.BoolValue
+	Owner    *wrapperspb.StringValue
+	Children []*EventMessage
+	Labels   map[string]*wrapperspb.StringValue
+}
+
+type Event struct {
+	Name     string
+	Time     time.Time
+	Timeout  time.Duration
+	Retries  int
+	Enabled  bool
+	Owner    string
+	Children []Event
+	Labels   map[string]string
+}
+
+func TestSource(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	src := &EventMessage{
+		Name:    wrapperspb.String("deploy"),
+		Time:    timestamppb.New(now),
+		Timeout: durationpb.New(time.Minute),
+		Retries: wrapperspb.Int32(3),
+		Enabled: wrapperspb.Bool(true),
+		Children: []*EventMessage{
+			{Name: wrapperspb.String("build"), Time: timestamppb.New(now.Add(time.Hour))},
+		},
+		Labels: map[string]*wrapperspb.StringValue{"env": wrapperspb.String("prod")},
+	}
+	dst := Event{Owner: "kept"}
+	if err := assign.ToFrom(&dst, Of(src)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	expected := Event{
+		Name:     "deploy",
+		Time:     now,
+		Timeout:  time.Minute,
+		Retries:  3,
+		Enabled:  true,
+		Owner:    "kept",
+		Children: []Event{{Name: "build", Time: now.Add(time.Hour)}},
+		Labels:   map[string]string{"env": "prod"},
+	}
+	if diff := cmp.Diff(expected, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+}
+
+func TestSourceMessage(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	var dst time.Time
+	if err := assign.ToFrom(&dst, Of(timestamppb.New(now))); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if !dst.Equal(now) {
+		t.Errorf("expected time: %v but found: %v", now, dst)
+	}
+}
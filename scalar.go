@@ -0,0 +1,115 @@
+package assign
+
+import (
+	"reflect"
+	"sync"
+)
+
+// scalarField is a field of a struct with only scalar fields, see scalarFieldsOf.
+type scalarField struct {
+	name    string
+	ignored bool
+}
+
+// cacheKey is the key of the caches of struct types by the type and tag keys, see ResetCache.
+type cacheKey struct {
+	typ  reflect.Type
+	tags string
+}
+
+// scalarCache caches the fields of struct types with only scalar fields by cacheKey.
+// Struct types with fields that are not scalar are cached as nil.
+var scalarCache sync.Map
+
+// scalarFieldsOf provides the fields of a struct type with only scalar fields, e.g. bool, int, string, etc.
+// Scalar fields are of the predeclared types so there are no named types to handle, e.g. time.Duration.
+// Fields with tag options are not scalar since they have defaults, directives, etc.
+// The result is false when the struct type has fields that are not scalar.
+func (a *Assigner) scalarFieldsOf(dt reflect.Type) ([]scalarField, bool) {
+	key := cacheKey{typ: dt, tags: a.tagKeys}
+	if fields, ok := scalarCache.Load(key); ok {
+		return fields.([]scalarField), fields.([]scalarField) != nil
+	}
+	n := dt.NumField()
+	fields := make([]scalarField, n)
+	for i := 0; i < n; i++ {
+		sf := dt.Field(i)
+		if _, ok := scalarSet[sf.Type.Kind()]; !ok || sf.Type.PkgPath() != "" {
+			fields = nil
+			break
+		}
+		tag := a.tagOf(sf)
+		if len(tag.opts) > 0 {
+			fields = nil
+			break
+		}
+		fields[i] = scalarField{name: tag.name, ignored: tag.ignored}
+	}
+	scalarCache.Store(key, fields)
+	return fields, fields != nil
+}
+
+var scalarSet = map[reflect.Kind]struct{}{
+	reflect.Bool:    {},
+	reflect.Int:     {},
+	reflect.Int8:    {},
+	reflect.Int16:   {},
+	reflect.Int32:   {},
+	reflect.Int64:   {},
+	reflect.Uint:    {},
+	reflect.Uint8:   {},
+	reflect.Uint16:  {},
+	reflect.Uint32:  {},
+	reflect.Uint64:  {},
+	reflect.Float32: {},
+	reflect.Float64: {},
+	reflect.String:  {},
+}
+
+// scalars reports whether structs with only scalar fields are assigned by assignScalars.
+// Options that handle fields or scalar values use the general path of assignStruct.
+func (a *Assigner) scalars() bool {
+	return !a.generic && !a.unexported && !a.getters && !a.changed && !a.required && len(a.srcTags) == 0 && a.fieldOrder == nil &&
+		len(a.strTrans) == 0 && len(a.valueTrans) == 0 && a.maxString == 0 && len(a.converters) == 0 &&
+		len(a.normalizers) == 0 && len(a.composites) == 0 && len(a.tagTrans) == 0 && a.unmatched == nil &&
+		len(a.enums.values) == 0 && len(a.enums.names) == 0
+}
+
+// assignScalars assigns a struct with only scalar fields, see scalarFieldsOf.
+// Source fields of the same type are set directly, otherwise the field is assigned by the general path.
+// This avoids the path, cycle checks and dispatch per field for the common case of flat structs.
+func (a *Assigner) assignScalars(ds reflect.Value, ss Source, fields []scalarField, md *metadata) error {
+	f := md.save()
+	defer md.restore(f)
+
+	descended := false
+	for i, field := range fields {
+		if field.ignored {
+			md.stats.Skipped++
+			continue
+		}
+		if !descended {
+			md.descend(f)
+			descended = true
+		}
+		df := ds.Field(i)
+		sf := ss.FieldByName(field.name)
+		if !df.CanSet() || sf.Skip() || !a.canInterface(sf) {
+			md.stats.Skipped++
+			continue
+		}
+		if a.cycle {
+			md.cur = 0
+		}
+		if sv := a.valueOf(sf); sv.Type() == df.Type() {
+			df.Set(sv)
+			md.stats.Assigned++
+			continue
+		}
+		md.field(f, ds.Type().Field(i).Name)
+		if err := a.assign(df, sf, md); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package assign
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// withGeneric forces the general path of assignStruct to compare with assignScalars.
+func withGeneric() Option {
+	return func(a *Assigner) {
+		a.generic = true
+	}
+}
+
+func TestAssignScalars(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		src     interface{}
+		options []Option
+	}{
+		{
+			name: "same type",
+			src:  scalarsValue,
+		},
+		{
+			name: "zero fields",
+			src:  Scalars{B: true, S: "s", I8: -8},
+		},
+		{
+			name: "other type",
+			src:  Small{Field: "field"},
+		},
+		{
+			name:    "map",
+			src:     map[string]interface{}{"S": "s", "I": int8(1), "F64": 1.5, "U8": 8},
+			options: []Option{WithMapToStruct()},
+		},
+		{
+			name:    "overflow",
+			src:     map[string]interface{}{"I8": 128},
+			options: []Option{WithMapToStruct(), WithOverflowCheck()},
+		},
+		{
+			name: "tags",
+			src:  ScalarTags{Name: "name", Count: 1, Ignored: "ignored"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			exp, act := Scalars{}, Scalars{}
+			expStats, expErr := From(test.src, append(test.options, withGeneric())...).ToStats(&exp)
+			actStats, actErr := From(test.src, test.options...).ToStats(&act)
+			if diff := cmp.Diff(exp, act, cmp.AllowUnexported(Scalars{})); diff != "" {
+				t.Errorf("(-expected +actual):\n%s\n%+v", diff, act)
+			}
+			if diff := cmp.Diff(expStats, actStats); diff != "" {
+				t.Errorf("(-expected +actual):\n%s\n%+v", diff, actStats)
+			}
+			if (expErr == nil) != (actErr == nil) || expErr != nil && expErr.Error() != actErr.Error() {
+				t.Errorf("expected error: %v but found: %v", expErr, actErr)
+			}
+		})
+	}
+}
+
+type Scalars struct {
+	B   bool
+	I   int
+	I8  int8
+	I16 int16
+	I32 int32
+	I64 int64
+	U   uint
+	U8  uint8
+	U16 uint16
+	U32 uint32
+	U64 uint64
+	F32 float32
+	F64 float64
+	S   string
+	S1  string
+	S2  string
+	S3  string
+	S4  string
+	S5  string
+	S6  string
+	s   string
+}
+
+type ScalarTags struct {
+	Name    string `assign:"S"`
+	Count   int    `assign:"I"`
+	Ignored string `assign:"-"`
+}
+
+var scalarsValue = Scalars{
+	B: true, I: 1, I8: 8, I16: 16, I32: 32, I64: 64,
+	U: 1, U8: 8, U16: 16, U32: 32, U64: 64, F32: 32.5, F64: 64.5,
+	S: "s", S1: "s1", S2: "s2", S3: "s3", S4: "s4", S5: "s5", S6: "s6", s: "s",
+}
+
+func BenchmarkAssignScalars(b *testing.B) {
+	benchmarks := []struct {
+		name    string
+		options []Option
+	}{
+		{name: "scalars"},
+		{name: "generic", options: []Option{withGeneric()}},
+	}
+	for _, bm := range benchmarks {
+		bm := bm
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			assigner := From(scalarsValue, bm.options...)
+			for i := 0; i < b.N; i++ {
+				dst := Scalars{}
+				if err := assigner.To(&dst); err != nil {
+					b.Fatalf("unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
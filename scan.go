@@ -0,0 +1,61 @@
+package assign
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var scanValuesType = reflect.TypeOf([]interface{}(nil))
+
+// ScanSource provides a positional Source of the values scanned from the input by the format with fmt.Sscanf,
+// e.g. the line "GET 200 0.25" scanned by "%s %d %f" is the slice of "GET", 200 and 0.25.
+// Integer verbs are scanned as int, floating-point verbs as float64, %t as bool, %c as rune
+// and other verbs as string.
+// See WithPositionalStruct option to assign the values to the fields of a struct in order,
+// which is useful for decoding fixed-format lines, e.g. of logs.
+// The input that fails to scan results in ErrorParse.
+func ScanSource(format, input string) (Source, error) {
+	values := scanValues(format)
+	if _, err := fmt.Sscanf(input, format, values...); err != nil {
+		return nil, newErrorParse(scanValuesType, input, err)
+	}
+	for i, v := range values {
+		values[i] = reflect.ValueOf(v).Elem().Interface()
+	}
+	return Of(values), nil
+}
+
+// scanValues provides a pointer to scan for each verb of the format.
+func scanValues(format string) []interface{} {
+	var values []interface{}
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		// Skip the flags, width and precision of the verb.
+		for i++; i < len(format) && isVerbModifier(format[i]); i++ {
+		}
+		if i == len(format) {
+			break
+		}
+		switch format[i] {
+		case '%':
+		case 'd', 'b', 'o', 'x', 'X':
+			values = append(values, new(int))
+		case 'e', 'E', 'f', 'F', 'g', 'G':
+			values = append(values, new(float64))
+		case 't':
+			values = append(values, new(bool))
+		case 'c':
+			values = append(values, new(rune))
+		default:
+			values = append(values, new(string))
+		}
+	}
+	return values
+}
+
+// isVerbModifier reports whether the byte is a flag, width or precision of a verb.
+func isVerbModifier(b byte) bool {
+	return b >= '0' && b <= '9' || b == '+' || b == '-' || b == '#' || b == ' ' || b == '.'
+}
@@ -0,0 +1,58 @@
+package assign
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestScanSource(t *testing.T) {
+	t.Parallel()
+
+	src, err := ScanSource("%s %d %f", "GET 200 0.25")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	dst := Request{}
+	if err := ToFrom(&dst, src, WithPositionalStruct()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	expected := Request{Method: "GET", Status: 200, Seconds: 0.25}
+	if diff := cmp.Diff(expected, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+
+	t.Run("values", func(t *testing.T) {
+		t.Parallel()
+		src, err := ScanSource("[%5s] 100%% %t %c %x", "[debug] 100% true x ff")
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		var dst []interface{}
+		if err := ToFrom(&dst, src); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		expected := []interface{}{"debug", true, 'x', 255}
+		if diff := cmp.Diff(expected, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		expErr := ErrorParse{}
+		if _, err := ScanSource("%s %d %f", "GET OK 0.25"); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+}
+
+type Request struct {
+	Method  string
+	Status  int
+	Seconds float64
+}
@@ -2,6 +2,8 @@ package assign
 
 import (
 	"reflect"
+	"sort"
+	"strings"
 )
 
 // Source represents any value which can be assigned to a Go value.
@@ -18,6 +20,7 @@ type Source interface {
 	Index(int) Source
 	// Pointer retrieves the underlying pointer of the value.
 	// This is used to prevent circular paths.
+	// Zero is for values that have no pointer to track.
 	// Disable this from being used with the WithoutCycle option.
 	Pointer() uintptr
 	// MapRange provides a map iterator.
@@ -30,14 +33,21 @@ type Source interface {
 	Interface() interface{}
 }
 
+// Fielder is implemented by struct sources that enumerate the names of their fields.
+// This is used to match fields by normalized names, see WithFieldNameNormalizer.
+type Fielder interface {
+	// Fields provides the names of the fields in a stable order.
+	Fields() []string
+}
+
 // Of provides a Source from any given value.
-// Handles Source directly, otherwise defaults to goSource
-// which handles reflect.Value as well.
+// Handles Source directly, generic maps and slices with anySource,
+// otherwise defaults to goSource which handles reflect.Value as well.
 func Of(i interface{}) Source {
 	if val, ok := i.(Source); ok {
 		return val
 	}
-	return &goSource{val: valueOf(i)}
+	return ofAny(i)
 }
 
 // valueOf provides the reflection value of the given Go value
@@ -66,6 +76,19 @@ func (v *goSource) FieldByName(name string) Source {
 	return &goSource{val: v.val.FieldByName(name)}
 }
 
+// Fields provides the names of the visible fields of a struct, including promoted fields.
+func (v *goSource) Fields() []string {
+	if v.val.Kind() != reflect.Struct {
+		return nil
+	}
+	fields := reflect.VisibleFields(v.val.Type())
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.Name
+	}
+	return names
+}
+
 func (v *goSource) Len() int {
 	return v.val.Len()
 }
@@ -74,7 +97,11 @@ func (v *goSource) Index(i int) Source {
 	return &goSource{val: v.val.Index(i)}
 }
 
+// Pointer is zero for kinds that have no pointer, e.g. arrays and structs.
 func (v *goSource) Pointer() uintptr {
+	if _, ok := ptrSet[v.val.Kind()]; !ok {
+		return 0
+	}
 	return v.val.Pointer()
 }
 
@@ -90,12 +117,174 @@ func (v *goSource) Interface() interface{} {
 	return v.val.Interface()
 }
 
-var _ Source = (*goSource)(nil)
+var (
+	_ Source  = (*goSource)(nil)
+	_ Fielder = (*goSource)(nil)
+)
+
+// mapStruct satisfies Source for a map assigned to a struct.
+// The map keys are the names of the struct fields.
+type mapStruct struct {
+	Source
+	fields map[string]Source
+	// dotted are the fields of dotted keys by the name before the first dot.
+	dotted map[string]map[string]Source
+}
+
+// newMapStruct creates a new mapStruct from a map Source.
+// Map keys that are not strings are ignored.
+// Dotted keys are nested by the name before the first dot when enabled.
+// Generic maps without dotted keys are used directly, see anyStruct.
+func newMapStruct(sm Source, dotted bool) Source {
+	if as, ok := sm.(*anySource); ok && !dotted {
+		return &anyStruct{anySource: as}
+	}
+	ms := &mapStruct{Source: sm, fields: make(map[string]Source, sm.Len())}
+	for mi := sm.MapRange(); mi.Next(); {
+		kv := reflect.ValueOf(mi.Key().Interface())
+		if kv.Kind() != reflect.String {
+			continue
+		}
+		name := kv.String()
+		ms.fields[name] = mi.Value()
+		if !dotted {
+			continue
+		}
+		if i := strings.IndexByte(name, '.'); i >= 0 {
+			if ms.dotted == nil {
+				ms.dotted = map[string]map[string]Source{}
+			}
+			prefix := name[:i]
+			if ms.dotted[prefix] == nil {
+				ms.dotted[prefix] = map[string]Source{}
+			}
+			ms.dotted[prefix][name[i+1:]] = mi.Value()
+		}
+	}
+	return ms
+}
+
+func (v *mapStruct) Kind() reflect.Kind {
+	return reflect.Struct
+}
+
+func (v *mapStruct) FieldByName(name string) Source {
+	if sf, ok := v.fields[name]; ok {
+		return sf
+	}
+	if fields, ok := v.dotted[name]; ok {
+		return &keysSource{fields: fields}
+	}
+	return Of(nil)
+}
+
+// Fields provides the sorted keys of the map, including the names before the first dot of dotted keys.
+func (v *mapStruct) Fields() []string {
+	names := make([]string, 0, len(v.fields)+len(v.dotted))
+	for name := range v.fields {
+		names = append(names, name)
+	}
+	for name := range v.dotted {
+		if _, ok := v.fields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+var (
+	_ Source  = (*mapStruct)(nil)
+	_ Fielder = (*mapStruct)(nil)
+)
+
+// keysSource satisfies Source for a map of string keys.
+type keysSource struct {
+	fields map[string]Source
+}
+
+func (v *keysSource) Kind() reflect.Kind {
+	return reflect.Map
+}
+
+func (v *keysSource) Elem() Source {
+	return v
+}
+
+func (v *keysSource) FieldByName(name string) Source {
+	if sf, ok := v.fields[name]; ok {
+		return sf
+	}
+	return Of(nil)
+}
+
+func (v *keysSource) Len() int {
+	return len(v.fields)
+}
+
+func (v *keysSource) Index(int) Source {
+	return Of(nil)
+}
+
+// Pointer is zero as the map is created by the package and has no cyclical paths.
+func (v *keysSource) Pointer() uintptr {
+	return 0
+}
+
+func (v *keysSource) MapRange() MapIter {
+	it := &keysMapIter{i: -1}
+	for key, val := range v.fields {
+		it.keys = append(it.keys, key)
+		it.vals = append(it.vals, val)
+	}
+	return it
+}
+
+func (v *keysSource) Skip() bool {
+	return len(v.fields) == 0
+}
+
+func (v *keysSource) Interface() interface{} {
+	m := make(map[string]interface{}, len(v.fields))
+	for key, val := range v.fields {
+		m[key] = val.Interface()
+	}
+	return m
+}
+
+var _ Source = (*keysSource)(nil)
+
+// keysMapIter satisfies MapIter for keysSource.
+type keysMapIter struct {
+	keys []string
+	vals []Source
+	i    int
+}
+
+func (m *keysMapIter) Next() bool {
+	m.i++
+	return m.i < len(m.keys)
+}
+
+func (m *keysMapIter) Key() Source {
+	return Of(m.keys[m.i])
+}
+
+func (m *keysMapIter) Value() Source {
+	return m.vals[m.i]
+}
+
+var _ MapIter = (*keysMapIter)(nil)
 
 // MapIter provides a way to iterate over maps types.
+// Maps are assigned in the order of the iterator,
+// custom Source types may provide any order, e.g. sorted or streaming keys.
 type MapIter interface {
+	// Next advances the iterator and reports whether there is another entry.
 	Next() bool
+	// Key is the key of the current entry.
 	Key() Source
+	// Value is the value of the current entry.
 	Value() Source
 }
 
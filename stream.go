@@ -0,0 +1,176 @@
+package assign
+
+import (
+	"errors"
+	"io"
+	"reflect"
+)
+
+// Decoder decodes the next record of a stream from the reader.
+// The record may be any Go value or Source.
+// io.EOF is returned when there are no more records.
+type Decoder func(io.Reader) (interface{}, error)
+
+// StreamSource is a Source of sequential records decoded from a stream.
+// Records are iterated with Next and Current:
+//
+//	for src.Next() {
+//		assign.ToFrom(&rec, src.Current())
+//	}
+//
+// As a Source, the stream is a slice of its records
+// which are read ahead by Index, Len and MapRange as needed.
+//
+// Records consumed by Next are released, so iterating a stream holds one record at a time.
+// Random access by Index, Len, MapRange or Interface keeps every record read from then on
+// so they may be indexed again, which holds the rest of the stream in memory.
+// Records released before random access are invalid when indexed.
+type StreamSource struct {
+	r       io.Reader
+	decode  Decoder
+	records []Source
+	base    int
+	pos     int
+	keep    bool
+	done    bool
+	err     error
+}
+
+// NewStreamSource creates a new StreamSource decoding records from the reader.
+func NewStreamSource(r io.Reader, decode Decoder) *StreamSource {
+	return &StreamSource{r: r, decode: decode, pos: -1}
+}
+
+// Next advances to the next record.
+// False is returned at the end of the stream or when decoding fails, see Err.
+func (s *StreamSource) Next() bool {
+	if s.pos < s.base+len(s.records) {
+		s.pos++
+	}
+	if !s.keep {
+		s.release(s.pos)
+	}
+	return s.readTo(s.pos)
+}
+
+// Current provides the record of the last call to Next.
+func (s *StreamSource) Current() Source {
+	if s.pos < s.base || s.pos >= s.base+len(s.records) {
+		return Of(nil)
+	}
+	return s.records[s.pos-s.base]
+}
+
+// Err provides the first error from decoding that is not io.EOF.
+func (s *StreamSource) Err() error {
+	return s.err
+}
+
+// release drops the records before the index so they may be collected.
+func (s *StreamSource) release(i int) {
+	n := i - s.base
+	if n <= 0 {
+		return
+	}
+	if n > len(s.records) {
+		n = len(s.records)
+	}
+	m := copy(s.records, s.records[n:])
+	for j := m; j < len(s.records); j++ {
+		s.records[j] = nil
+	}
+	s.records = s.records[:m]
+	s.base += n
+}
+
+// readTo reads records until the index is read.
+// False is returned when the stream ends before the index.
+func (s *StreamSource) readTo(i int) bool {
+	for !s.done && i >= s.base+len(s.records) {
+		rec, err := s.decode(s.r)
+		if err != nil {
+			s.done = true
+			if !errors.Is(err, io.EOF) {
+				s.err = err
+			}
+			break
+		}
+		s.records = append(s.records, Of(rec))
+	}
+	return i < s.base+len(s.records)
+}
+
+func (s *StreamSource) Kind() reflect.Kind {
+	return reflect.Slice
+}
+
+// Elem is the stream itself since it is not a pointer or interface.
+func (s *StreamSource) Elem() Source {
+	return s
+}
+
+// FieldByName is invalid since the stream is not a struct.
+func (s *StreamSource) FieldByName(string) Source {
+	return Of(nil)
+}
+
+// Len reads the remaining records of the stream.
+func (s *StreamSource) Len() int {
+	s.keep = true
+	for s.readTo(s.base + len(s.records)) {
+	}
+	return s.base + len(s.records)
+}
+
+func (s *StreamSource) Index(i int) Source {
+	s.keep = true
+	if i < s.base || !s.readTo(i) {
+		return Of(nil)
+	}
+	return s.records[i-s.base]
+}
+
+// Pointer is zero since the stream is not tracked for cycles.
+func (s *StreamSource) Pointer() uintptr {
+	return 0
+}
+
+// MapRange iterates the records keyed by index which are read ahead as needed.
+func (s *StreamSource) MapRange() MapIter {
+	s.keep = true
+	return &streamMapIter{s: s, i: -1}
+}
+
+func (s *StreamSource) Skip() bool {
+	return false
+}
+
+// Interface provides all records of the stream.
+func (s *StreamSource) Interface() interface{} {
+	records := make([]interface{}, s.Len())
+	for i := range records {
+		records[i] = s.Index(i).Interface()
+	}
+	return records
+}
+
+var _ Source = (*StreamSource)(nil)
+
+// streamMapIter satisfies MapIter for the records of a stream keyed by index.
+type streamMapIter struct {
+	s *StreamSource
+	i int
+}
+
+func (it *streamMapIter) Next() bool {
+	it.i++
+	return it.s.readTo(it.i)
+}
+
+func (it *streamMapIter) Key() Source {
+	return Of(it.i)
+}
+
+func (it *streamMapIter) Value() Source {
+	return it.s.Index(it.i)
+}
@@ -0,0 +1,148 @@
+package assign
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStreamSource(t *testing.T) {
+	t.Parallel()
+
+	exp := []Small{{Field: "first"}, {Field: "second"}, {Field: "third"}}
+
+	t.Run("next", func(t *testing.T) {
+		t.Parallel()
+		src := NewStreamSource(newStream(exp), decodeSmall)
+		act := []Small{}
+		for src.Next() {
+			rec := Small{}
+			if err := ToFrom(&rec, src.Current()); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			act = append(act, rec)
+		}
+		if err := src.Err(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if diff := cmp.Diff(exp, act); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, act)
+		}
+	})
+	t.Run("slice", func(t *testing.T) {
+		t.Parallel()
+		src := NewStreamSource(newStream(exp), decodeSmall)
+		var act []Small
+		if err := ToFrom(&act, src); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if diff := cmp.Diff(exp, act); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, act)
+		}
+	})
+	t.Run("index", func(t *testing.T) {
+		t.Parallel()
+		src := NewStreamSource(newStream(exp), decodeSmall)
+		if rec := src.Index(2).Interface(); rec != exp[2] {
+			t.Errorf("expected record: %v but found: %v", exp[2], rec)
+		}
+		if !src.Next() || src.Current().Interface() != exp[0] {
+			t.Errorf("expected record: %v but found: %v", exp[0], src.Current().Interface())
+		}
+		if !src.Index(3).Skip() {
+			t.Errorf("expected record out of range to be skipped")
+		}
+	})
+	t.Run("release", func(t *testing.T) {
+		t.Parallel()
+		src := NewStreamSource(newStream(exp), decodeSmall)
+		for src.Next() {
+			if n := len(src.records); n != 1 {
+				t.Errorf("expected kept records: %d but found: %d", 1, n)
+			}
+		}
+		if !src.Index(0).Skip() {
+			t.Errorf("expected released record to be skipped")
+		}
+	})
+	t.Run("keep", func(t *testing.T) {
+		t.Parallel()
+		src := NewStreamSource(newStream(exp), decodeSmall)
+		if !src.Next() || !src.Next() {
+			t.Errorf("expected records")
+			return
+		}
+		if rec := src.Index(2).Interface(); rec != exp[2] {
+			t.Errorf("expected record: %v but found: %v", exp[2], rec)
+		}
+		if !src.Next() || src.Current().Interface() != exp[2] {
+			t.Errorf("expected record: %v but found: %v", exp[2], src.Current().Interface())
+		}
+		if rec := src.Index(1).Interface(); rec != exp[1] {
+			t.Errorf("expected record: %v but found: %v", exp[1], rec)
+		}
+		if !src.Index(0).Skip() {
+			t.Errorf("expected released record to be skipped")
+		}
+	})
+	t.Run("map range", func(t *testing.T) {
+		t.Parallel()
+		src := NewStreamSource(newStream(exp), decodeSmall)
+		act := map[int]Small{}
+		for mi := src.MapRange(); mi.Next(); {
+			rec := Small{}
+			if err := ToFrom(&rec, mi.Value()); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			act[mi.Key().Interface().(int)] = rec
+		}
+		if diff := cmp.Diff(map[int]Small{0: exp[0], 1: exp[1], 2: exp[2]}, act); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, act)
+		}
+	})
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		stream := newStream(exp)
+		stream.Truncate(stream.Len() - 1)
+		src := NewStreamSource(stream, decodeSmall)
+		n := 0
+		for src.Next() {
+			n++
+		}
+		if n != 2 {
+			t.Errorf("expected records: %d but found: %d", 2, n)
+		}
+		if err := src.Err(); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("expected error: %v but found: %v", io.ErrUnexpectedEOF, err)
+		}
+	})
+}
+
+// newStream encodes the records with a length prefix.
+func newStream(records []Small) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	for _, rec := range records {
+		buf.WriteByte(byte(len(rec.Field)))
+		buf.WriteString(rec.Field)
+	}
+	return buf
+}
+
+// decodeSmall decodes a record with a length prefix.
+func decodeSmall(r io.Reader) (interface{}, error) {
+	n := [1]byte{}
+	if _, err := io.ReadFull(r, n[:]); err != nil {
+		return nil, err
+	}
+	field := make([]byte, n[0])
+	if _, err := io.ReadFull(r, field); err != nil {
+		return nil, err
+	}
+	return Small{Field: string(field)}, nil
+}
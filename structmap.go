@@ -0,0 +1,129 @@
+package assign
+
+import (
+	"reflect"
+)
+
+// namedSource is a field of a struct source named by its tag.
+type namedSource struct {
+	name string
+	src  Source
+}
+
+// fieldsOf provides the fields of the struct source.
+// Go structs provide their exported fields in the order of declaration named by tags,
+// fields tagged with `-` are omitted.
+// Other sources provide their fields in the order of Fielder.
+// The result is false for sources that are not Go structs or Fielder.
+func (a *Assigner) fieldsOf(ss Source) ([]namedSource, bool) {
+	if gs, ok := ss.(*goSource); ok {
+		st := gs.val.Type()
+		n := st.NumField()
+		fields := make([]namedSource, 0, n)
+		for i := 0; i < n; i++ {
+			sf := st.Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			tag := a.tagOf(sf)
+			if tag.ignored {
+				continue
+			}
+			fields = append(fields, namedSource{name: tag.name, src: &goSource{val: gs.val.Field(i)}})
+		}
+		return fields, true
+	}
+	fs, ok := ss.(Fielder)
+	if !ok {
+		return nil, false
+	}
+	names := fs.Fields()
+	fields := make([]namedSource, len(names))
+	for i, name := range names {
+		fields[i] = namedSource{name: name, src: ss.FieldByName(name)}
+	}
+	return fields, true
+}
+
+// assignFromStruct assigns to a map of string keys from the fields of a struct.
+// Nested structs are flattened into keys joined by the separator of the WithFlatten option.
+func (a *Assigner) assignFromStruct(dm reflect.Value, ss Source, md *metadata) error {
+	dt := dm.Type()
+	if dt.Key().Kind() != reflect.String {
+		return newError(dt, ss.Kind())
+	}
+	if dm.IsNil() {
+		dm.Set(reflect.MakeMap(dt))
+	}
+
+	f := md.save()
+	defer md.restore(f)
+
+	return a.assignFields(dm, ss, "", f, md)
+}
+
+// assignFields assigns the fields of the struct to the map with keys of the prefix.
+func (a *Assigner) assignFields(dm reflect.Value, ss Source, prefix string, f frame, md *metadata) error {
+	dt := dm.Type()
+	fields, ok := a.fieldsOf(ss)
+	if !ok {
+		return newError(dt, ss.Kind())
+	}
+	for _, field := range fields {
+		key := prefix + field.name
+		sf := field.src
+		if a.flatten != "" {
+			if nested, ok := flattened(sf); ok {
+				if nested == nil {
+					continue
+				}
+				if err := a.assignFields(dm, nested, key+a.flatten, f, md); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if err := a.element(dt, md); err != nil {
+			return err
+		}
+		dk := a.newValue(dt.Key())
+		dk.SetString(key)
+		dv := a.newValue(dt.Elem())
+		md.index(f, key)
+		if err := a.assign(dv, sf, md); err != nil {
+			return err
+		}
+		// Values are checked once assigned as nested zero values are skipped as well.
+		if a.omitEmpty && dv.IsZero() {
+			continue
+		}
+		dm.SetMapIndex(dk, dv)
+	}
+	return nil
+}
+
+// flattened provides the nested struct of the field source to flatten, dereferencing pointers.
+// The nested struct is nil for a nil pointer to a struct, which has no fields to flatten.
+// The result is false for fields that are not structs with exported fields, e.g. time.Time.
+func flattened(sf Source) (Source, bool) {
+	for sf.Kind() == reflect.Ptr || sf.Kind() == reflect.Interface {
+		gs, ok := sf.(*goSource)
+		if !ok {
+			return nil, false
+		}
+		if gs.val.IsNil() {
+			if elem := gs.val.Type().Elem(); elem.Kind() == reflect.Struct && hasExported(elem) {
+				return nil, true
+			}
+			return nil, false
+		}
+		sf = sf.Elem()
+	}
+	if sf.Kind() != reflect.Struct {
+		return nil, false
+	}
+	if gs, ok := sf.(*goSource); ok && !hasExported(gs.val.Type()) {
+		return nil, false
+	}
+	return sf, true
+}
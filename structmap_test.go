@@ -0,0 +1,108 @@
+package assign
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAssignWithStructToMap(t *testing.T) {
+	t.Parallel()
+
+	src := JSONUser{Name: "Ada", Password: "secret"}
+	dst := map[string]interface{}{}
+	if err := ToFrom(&dst, src, WithStructToMap(), WithJSONTags()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	// Zero values are skipped as with any destination, which leaves the zero value of the map.
+	exp := map[string]interface{}{"name": "Ada", "email": nil, "-": nil}
+	if diff := cmp.Diff(exp, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+}
+
+func TestAssignWithFlatten(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	src := Profile{
+		Name:    "Ada",
+		Address: Location{City: "London", Geo: &Coordinates{Lat: 51.5}},
+		Created: created,
+	}
+	dst := map[string]interface{}{}
+	if err := ToFrom(&dst, src, WithFlatten("."), WithTags("key")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	exp := map[string]interface{}{
+		"name":             "Ada",
+		"address.city":     "London",
+		"address.geo.lat":  51.5,
+		"address.geo.long": nil,
+		"created":          created,
+	}
+	if diff := cmp.Diff(exp, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+		src := Profile{Address: Location{City: "Paris"}}
+		dst := map[string]string{}
+		if err := ToFrom(&dst, src, WithFlatten("_"), WithTags("key")); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := map[string]string{"name": "", "address_city": "Paris", "created": ""}
+		if diff := cmp.Diff(exp, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+}
+
+func TestAssignWithOmitEmpty(t *testing.T) {
+	t.Parallel()
+
+	src := Profile{Name: "Ada", Address: Location{Geo: &Coordinates{Lat: 51.5}}}
+	dst := map[string]interface{}{}
+	if err := ToFrom(&dst, src, WithFlatten("."), WithTags("key"), WithOmitEmpty()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	exp := map[string]interface{}{"name": "Ada", "address.geo.lat": 51.5}
+	if diff := cmp.Diff(exp, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+
+	t.Run("struct", func(t *testing.T) {
+		t.Parallel()
+		src := Config{Name: "service", Owner: &Small{}}
+		dst := map[string]interface{}{}
+		if err := ToFrom(&dst, src, WithStructToMap(), WithOmitEmpty()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if diff := cmp.Diff(map[string]interface{}{"Name": "service"}, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+}
+
+type Profile struct {
+	Name    string    `key:"name"`
+	Address Location  `key:"address"`
+	Created time.Time `key:"created"`
+}
+
+type Location struct {
+	City string       `key:"city"`
+	Geo  *Coordinates `key:"geo"`
+}
+
+type Coordinates struct {
+	Lat  float64 `key:"lat"`
+	Long float64 `key:"long"`
+}
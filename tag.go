@@ -0,0 +1,353 @@
+package assign
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// fieldTag is the parsed tag of a struct field, e.g. `assign:"name,required,default=value"`.
+// The name is the first element, the options follow separated by commas.
+type fieldTag struct {
+	name string
+	opts []string
+	// ignored is a field tagged with `-` by a key following JSON conventions, see WithJSONTags.
+	// A field is named `-` with the tag `-,`.
+	ignored bool
+}
+
+// parseTag parses the tag value of a struct field.
+// The tag `-` ignores the field when dash is set, otherwise it is the name of the field.
+func parseTag(tag string, dash bool) fieldTag {
+	if dash && tag == "-" {
+		return fieldTag{ignored: true}
+	}
+	parts := splitTag(tag)
+	return fieldTag{name: parts[0], opts: parts[1:]}
+}
+
+// splitTag splits the tag value by commas outside of single quotes,
+// e.g. `assign:"tags,default='a,b'"` has the option `default='a,b'`.
+func splitTag(tag string) []string {
+	var parts []string
+	quoted, start := false, 0
+	for i := 0; i < len(tag); i++ {
+		switch tag[i] {
+		case '\'':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				parts = append(parts, tag[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, tag[start:])
+}
+
+// has reports whether the tag has the option.
+func (t fieldTag) has(opt string) bool {
+	for _, o := range t.opts {
+		if o == opt {
+			return true
+		}
+	}
+	return false
+}
+
+// value provides the value of the option by key, e.g. `default=value`.
+// Values in single quotes are unquoted, e.g. `default='a,b'` is `a,b`.
+func (t fieldTag) value(key string) (string, bool) {
+	for _, o := range t.opts {
+		if k, v, ok := strings.Cut(o, "="); ok && k == key {
+			if len(v) >= 2 && v[0] == '\'' && v[len(v)-1] == '\'' {
+				v = v[1 : len(v)-1]
+			}
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// tagOf provides the tag of the first matched tag key, otherwise the field name without options.
+// The name of a matched tag is transformed, see WithTagTransform.
+// A tag with an empty name, e.g. `assign:",required"`, is named by the field name.
+// A field tagged `json:"-"` is ignored with the WithJSONTags option.
+// The first and default tag key is `assign`, see WithTags option to include tag keys.
+func (a *Assigner) tagOf(sf reflect.StructField) fieldTag {
+	for _, key := range a.tags {
+		if tag := sf.Tag.Get(key); tag != "" {
+			ft := parseTag(tag, a.dashTag(key))
+			for _, fn := range a.tagTrans {
+				ft.name = fn(ft.name)
+			}
+			if ft.name == "" {
+				ft.name = sf.Name
+			}
+			return ft
+		}
+	}
+	return fieldTag{name: sf.Name}
+}
+
+// dashTag reports whether the tag `-` ignores fields for the tag key,
+// which is only the `json` key with the WithJSONTags option.
+func (a *Assigner) dashTag(key string) bool {
+	return a.jsonTags && key == "json"
+}
+
+// tagCache caches the tags of the fields of struct types by cacheKey, see WithStructTagCache.
+var tagCache sync.Map
+
+// tagsOf provides the tags of the fields of the struct type by index from the cache shared by all assigners.
+// Nil is provided without the WithStructTagCache option or with tag transforms,
+// which are functions that cannot be keyed, see tagOf to parse the tag of each field instead.
+func (a *Assigner) tagsOf(dt reflect.Type) []fieldTag {
+	if !a.tagCache || len(a.tagTrans) > 0 {
+		return nil
+	}
+	key := cacheKey{typ: dt, tags: a.tagKeys}
+	if tags, ok := tagCache.Load(key); ok {
+		return tags.([]fieldTag)
+	}
+	tags := make([]fieldTag, dt.NumField())
+	for i := range tags {
+		tags[i] = a.tagOf(dt.Field(i))
+	}
+	tagCache.Store(key, tags)
+	return tags
+}
+
+// ResetCache clears the caches of struct types shared by all assigners,
+// e.g. to measure the first assignment of a type in tests and benchmarks.
+func ResetCache() {
+	for _, cache := range []*sync.Map{&tagCache, &scalarCache} {
+		cache.Range(func(key, _ interface{}) bool {
+			cache.Delete(key)
+			return true
+		})
+	}
+}
+
+// sourceTagged provides the field of the struct source named by the first matched source tag key,
+// see WithSourceTags option.
+// The result is false when no field is named by the tag keys.
+func (a *Assigner) sourceTagged(sv reflect.Value, name string) (Source, bool) {
+	if sv.Kind() != reflect.Struct {
+		return nil, false
+	}
+	st := sv.Type()
+	for i := 0; i < st.NumField(); i++ {
+		sf := st.Field(i)
+		for _, key := range a.srcTags {
+			tag := sf.Tag.Get(key)
+			if tag == "" {
+				continue
+			}
+			if ft := parseTag(tag, a.dashTag(key)); !ft.ignored && ft.name == name {
+				return &goSource{val: sv.Field(i)}, true
+			}
+			break
+		}
+	}
+	return nil, false
+}
+
+// assignDefault assigns the default of the tag to a field destination that is zero, see assignParsed.
+func (a *Assigner) assignDefault(df reflect.Value, def string, md *metadata) error {
+	if !df.CanSet() || !df.IsZero() {
+		md.stats.Skipped++
+		return nil
+	}
+	return a.assignParsed(df, def, md)
+}
+
+// assignParsed assigns the string parsed by the kind of the destination.
+// Other destinations are assigned from the string as a source, e.g. time.Time.
+// Strings that fail to parse result in ErrorParse.
+func (a *Assigner) assignParsed(db reflect.Value, s string, md *metadata) error {
+	dt := db.Type()
+	var err error
+	switch dk := dt.Kind(); {
+	case dk == reflect.String:
+		db.SetString(s)
+	case dk == reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(s); err == nil {
+			db.SetBool(b)
+		}
+	case isKind(intSet, dk) && dt != durationType:
+		var n int64
+		if n, err = strconv.ParseInt(s, 10, dt.Bits()); err == nil {
+			db.SetInt(n)
+		}
+	case isKind(uintSet, dk):
+		var n uint64
+		if n, err = strconv.ParseUint(s, 10, dt.Bits()); err == nil {
+			db.SetUint(n)
+		}
+	case dk == reflect.Float32 || dk == reflect.Float64:
+		var f float64
+		if f, err = strconv.ParseFloat(s, dt.Bits()); err == nil {
+			db.SetFloat(f)
+		}
+	default:
+		return a.assign(db, Of(s), md)
+	}
+	if err != nil {
+		return newErrorParse(dt, s, err)
+	}
+	md.stats.Assigned++
+	return nil
+}
+
+// assignDirective assigns the field with the conversion directive of the tag, e.g. `assign:"amount,string"`.
+// The directives are:
+//   - string: the source is a string parsed by the kind of the field, see assignParsed.
+//   - number: the source is a number formatted to a string field.
+//   - base64: the source is a base64 string decoded to a byte slice field.
+//   - time: the source is a string parsed to a time.Time or time.Duration field.
+//
+// Pointer fields are allocated and their values are assigned.
+// The handled result is false when the tag has no directive for the field.
+func (a *Assigner) assignDirective(df reflect.Value, sf Source, tag fieldTag, md *metadata) (bool, error) {
+	directive := ""
+	for _, d := range []string{"string", "number", "base64", "time"} {
+		if tag.has(d) {
+			directive = d
+			break
+		}
+	}
+	if directive == "" || !df.CanSet() {
+		return false, nil
+	}
+	for df.Kind() == reflect.Ptr {
+		if df.IsNil() {
+			df.Set(a.newValue(df.Type().Elem()).Addr())
+		}
+		df = df.Elem()
+	}
+	dt := df.Type()
+	sv := a.valueOf(sf)
+	switch directive {
+	case "string":
+		if sv.Kind() != reflect.String {
+			return true, newError(dt, sv.Kind())
+		}
+		return true, a.assignParsed(df, sv.String(), md)
+	case "number":
+		if dt.Kind() != reflect.String {
+			return false, nil
+		}
+		var s string
+		switch sk := sv.Kind(); {
+		case isKind(intSet, sk):
+			s = strconv.FormatInt(sv.Int(), 10)
+		case isKind(uintSet, sk):
+			s = strconv.FormatUint(sv.Uint(), 10)
+		case sk == reflect.Float32 || sk == reflect.Float64:
+			s = strconv.FormatFloat(sv.Float(), 'g', -1, sv.Type().Bits())
+		default:
+			return true, newError(dt, sk)
+		}
+		df.SetString(s)
+		md.stats.Assigned++
+		return true, nil
+	case "base64":
+		if sv.Kind() != reflect.String || !isBytes(dt) {
+			return true, newError(dt, sv.Kind())
+		}
+		return true, a.assignFromBase64(df, sf, md)
+	default:
+		if sv.Kind() != reflect.String {
+			return true, newError(dt, sv.Kind())
+		}
+		if dt == durationType {
+			return true, a.assignDuration(df, sv, md)
+		}
+		if dt != timeType {
+			return true, newError(dt, sv.Kind())
+		}
+		return true, a.assignTime(df, sf, md)
+	}
+}
+
+// validate validates the field by the rules of the tag once assigned, e.g. `assign:"age,min=0,max=150"`.
+// The rules are:
+//   - min and max: the number is within the bounds, inclusive.
+//   - len: the length of the string, slice, array or map is equal.
+//   - nonzero: the value is not zero.
+//
+// Pointers are dereferenced, nil pointers only violate nonzero.
+// Rules that do not apply to the kind of the field are ignored,
+// as are fields absent from the source without a default.
+// The first violated rule results in ErrorValidation, rules that fail to parse result in ErrorParse.
+func validate(df reflect.Value, tag fieldTag, md *metadata) error {
+	for _, opt := range tag.opts {
+		key, value, _ := strings.Cut(opt, "=")
+		switch key {
+		case "min", "max", "len", "nonzero":
+		default:
+			continue
+		}
+		dv := df
+		for dv.Kind() == reflect.Ptr && !dv.IsNil() {
+			dv = dv.Elem()
+		}
+		if key == "nonzero" {
+			if dv.IsZero() {
+				return newErrorValidation(df.Type(), md.path(), opt)
+			}
+			continue
+		}
+		if dv.Kind() == reflect.Ptr {
+			continue
+		}
+		ok, err := satisfies(dv, key, value)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return newErrorValidation(df.Type(), md.path(), opt)
+		}
+	}
+	return nil
+}
+
+// satisfies reports whether the value satisfies the rule of the key and value.
+// Values of kinds that the rule does not apply to satisfy it.
+func satisfies(dv reflect.Value, key, value string) (bool, error) {
+	dk := dv.Kind()
+	if key == "len" {
+		switch dk {
+		case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		default:
+			return true, nil
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return false, newErrorParse(dv.Type(), value, err)
+		}
+		return dv.Len() == n, nil
+	}
+	var f float64
+	switch {
+	case isKind(intSet, dk):
+		f = float64(dv.Int())
+	case isKind(uintSet, dk):
+		f = float64(dv.Uint())
+	case dk == reflect.Float32 || dk == reflect.Float64:
+		f = dv.Float()
+	default:
+		return true, nil
+	}
+	bound, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return false, newErrorParse(dv.Type(), value, err)
+	}
+	if key == "min" {
+		return f >= bound, nil
+	}
+	return f <= bound, nil
+}
@@ -0,0 +1,566 @@
+package assign
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAssignWithRequiredTag(t *testing.T) {
+	t.Parallel()
+
+	t.Run("present", func(t *testing.T) {
+		t.Parallel()
+		src := map[string]interface{}{"name": "service", "port": 80}
+		dst := Required{}
+		if err := ToFrom(&dst, src, WithMapToStruct(), WithRequiredTag()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := Required{Name: "service", Port: 80}
+		if diff := cmp.Diff(exp, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+	t.Run("absent", func(t *testing.T) {
+		t.Parallel()
+		src := map[string]interface{}{"port": 80}
+		dst := Required{}
+		expErr := ErrorMissingField{}
+		if err := ToFrom(&dst, src, WithMapToStruct(), WithRequiredTag()); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+			return
+		}
+		if expErr.Name != "name" || expErr.Path != "Name" {
+			t.Errorf("expected name: %q and path: %q but found: %q and %q", "name", "Name", expErr.Name, expErr.Path)
+		}
+	})
+	t.Run("absent with default", func(t *testing.T) {
+		t.Parallel()
+		src := map[string]interface{}{"name": "service"}
+		dst := Required{}
+		if err := ToFrom(&dst, src, WithMapToStruct(), WithRequiredTag()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := Required{Name: "service", Port: 8080}
+		if diff := cmp.Diff(exp, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+	t.Run("without option", func(t *testing.T) {
+		t.Parallel()
+		dst := Required{}
+		if err := ToFrom(&dst, map[string]interface{}{}, WithMapToStruct()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+type Required struct {
+	Name string `assign:"name,required"`
+	Port int    `assign:"port,required,default=8080"`
+}
+
+func TestAssignWithDefaultTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  map[string]interface{}
+		exp  Defaults
+	}{
+		{
+			name: "absent",
+			src:  map[string]interface{}{},
+			exp:  Defaults{Name: "guest", Retries: 3, Enabled: true, Tags: "a,b"},
+		},
+		{
+			name: "zero",
+			src:  map[string]interface{}{"name": "", "retries": 0, "enabled": false, "tags": ""},
+			exp:  Defaults{Name: "guest", Retries: 3, Enabled: true, Tags: "a,b"},
+		},
+		{
+			name: "present",
+			src:  map[string]interface{}{"name": "Ada", "retries": 1, "tags": "c"},
+			exp:  Defaults{Name: "Ada", Retries: 1, Enabled: true, Tags: "c"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			dst := Defaults{}
+			if err := ToFrom(&dst, test.src, WithMapToStruct()); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if diff := cmp.Diff(test.exp, dst); diff != "" {
+				t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+			}
+		})
+	}
+}
+
+type Defaults struct {
+	Name    string `assign:"name,default=guest"`
+	Retries int    `assign:"retries,default=3"`
+	Enabled bool   `assign:"enabled,default=true"`
+	Tags    string `assign:"tags,default='a,b',required"`
+}
+
+func TestAssignWithOnlyTags(t *testing.T) {
+	t.Parallel()
+
+	src := map[string]interface{}{"name": "Ada", "Name": "Lovelace", "full_name": "Ada Lovelace"}
+	tests := []struct {
+		name    string
+		options []Option
+		exp     OnlyTags
+	}{
+		{
+			name:    "tags",
+			options: []Option{WithTags("json")},
+			exp:     OnlyTags{Name: "Ada", FullName: "Ada"},
+		},
+		{
+			name:    "only tags",
+			options: []Option{WithOnlyTags("json")},
+			exp:     OnlyTags{Name: "Lovelace", FullName: "Ada Lovelace"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			dst := OnlyTags{}
+			if err := ToFrom(&dst, src, append(test.options, WithMapToStruct())...); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if diff := cmp.Diff(test.exp, dst); diff != "" {
+				t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+			}
+		})
+	}
+}
+
+type OnlyTags struct {
+	Name     string `assign:"name"`
+	FullName string `assign:"name" json:"full_name"`
+}
+
+func TestAssignWithSourceTags(t *testing.T) {
+	t.Parallel()
+
+	src := UserRow{ID: 1, Name: "Ada", Email: "ada@example.com", Secret: "secret"}
+	dst := UserJSON{}
+	if err := ToFrom(&dst, src, WithJSONTags(), WithSourceTags("db")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	exp := UserJSON{UserID: 1, FullName: "Ada", Email: "ada@example.com"}
+	if diff := cmp.Diff(exp, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+
+	t.Run("without option", func(t *testing.T) {
+		t.Parallel()
+		dst := UserJSON{}
+		if err := ToFrom(&dst, src, WithJSONTags()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if diff := cmp.Diff(UserJSON{Email: "ada@example.com"}, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+}
+
+type UserRow struct {
+	ID     int    `db:"user_id"`
+	Name   string `db:"full_name"`
+	Email  string `db:"email_address"`
+	Secret string `db:"-"`
+}
+
+type UserJSON struct {
+	UserID   int    `json:"user_id"`
+	FullName string `json:"full_name"`
+	Email    string `json:"Email"`
+	Secret   string `json:"secret"`
+}
+
+func TestAssignValidationTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		src  map[string]interface{}
+		path string
+		rule string
+	}{
+		{
+			name: "valid",
+			src:  map[string]interface{}{"name": "Ada", "age": 36, "code": "GB", "tags": []string{"a"}},
+		},
+		{
+			name: "min",
+			src:  map[string]interface{}{"name": "Ada", "age": -1, "code": "GB"},
+			path: "Age",
+			rule: "min=0",
+		},
+		{
+			name: "max",
+			src:  map[string]interface{}{"name": "Ada", "age": 151, "code": "GB"},
+			path: "Age",
+			rule: "max=150",
+		},
+		{
+			name: "len",
+			src:  map[string]interface{}{"name": "Ada", "code": "GBR"},
+			path: "Code",
+			rule: "len=2",
+		},
+		{
+			name: "nonzero",
+			src:  map[string]interface{}{"name": "", "code": "GB"},
+			path: "Name",
+			rule: "nonzero",
+		},
+		{
+			name: "absent",
+			src:  map[string]interface{}{"code": "GB"},
+		},
+		{
+			name: "nested",
+			src:  map[string]interface{}{"name": "Ada", "code": "GB", "parent": map[string]interface{}{"name": "Anne", "code": "G"}},
+			path: "Parent.Code",
+			rule: "len=2",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			dst := Validated{}
+			err := ToFrom(&dst, test.src, WithMapToStruct())
+			if test.rule == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			expErr := ErrorValidation{}
+			if !errors.As(err, &expErr) {
+				t.Errorf("expected type: %T but found: %T", expErr, err)
+				return
+			}
+			if expErr.Path != test.path || expErr.Rule != test.rule {
+				t.Errorf("expected path: %q and rule: %q but found: %q and %q", test.path, test.rule, expErr.Path, expErr.Rule)
+			}
+		})
+	}
+}
+
+func TestAssignValidationTagsWithErrorAggregation(t *testing.T) {
+	t.Parallel()
+
+	type Bounded struct {
+		A int `assign:"A,min=10"`
+		B int
+		C int `assign:"C,max=1"`
+		D int `assign:"D,min=1"`
+	}
+	dst := Bounded{}
+	err := ToFrom(&dst, map[string]interface{}{"A": 1, "B": 2, "C": 3}, WithMapToStruct(), WithErrorAggregation())
+	expErr := ErrorMultiple{}
+	if !errors.As(err, &expErr) {
+		t.Errorf("expected type: %T but found: %T", expErr, err)
+		return
+	}
+	if diff := cmp.Diff([]string{"A", "C"}, expErr.Paths); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, expErr.Paths)
+	}
+	for _, err := range expErr.Errors {
+		if !errors.As(err, &ErrorValidation{}) {
+			t.Errorf("expected type: %T but found: %T", ErrorValidation{}, err)
+		}
+	}
+	if diff := cmp.Diff(Bounded{A: 1, B: 2, C: 3}, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+}
+
+type Validated struct {
+	Name   string     `assign:"name,nonzero"`
+	Age    int        `assign:"age,min=0,max=150"`
+	Code   string     `assign:"code,len=2"`
+	Tags   []string   `assign:"tags,max=1"`
+	Parent *Validated `assign:"parent"`
+}
+
+func TestAssignWithTagTransform(t *testing.T) {
+	t.Parallel()
+
+	strip := func(name string) string {
+		return strings.TrimPrefix(name, "db.")
+	}
+	src := map[string]interface{}{"id": 1, "name": "Ada", "email": "ada@example.com", "Email": "field", "db.id": 2}
+	tests := []struct {
+		name    string
+		options []Option
+		exp     Namespaced
+	}{
+		{
+			name:    "stripped",
+			options: []Option{WithTagTransform(strip)},
+			exp:     Namespaced{ID: 1, Name: "Ada", Email: "ada@example.com"},
+		},
+		{
+			name: "without option",
+			exp:  Namespaced{ID: 2},
+		},
+		{
+			name: "empty",
+			options: []Option{WithTagTransform(func(string) string {
+				return ""
+			})},
+			exp: Namespaced{Email: "field"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			act := Namespaced{}
+			if err := ToFrom(&act, src, append(test.options, WithMapToStruct(), WithTags("db"))...); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if diff := cmp.Diff(test.exp, act); diff != "" {
+				t.Errorf("(-expected +actual):\n%s\n%+v", diff, act)
+			}
+		})
+	}
+}
+
+type Namespaced struct {
+	ID    int    `db:"db.id"`
+	Name  string `db:"db.name"`
+	Email string `db:"db.email"`
+}
+
+func TestAssignWithJSONTags(t *testing.T) {
+	t.Parallel()
+
+	src := map[string]interface{}{
+		"name":     "Ada",
+		"email":    "ada@example.com",
+		"password": "secret",
+		"Password": "secret",
+		"-":        "dash",
+	}
+	dst := JSONUser{}
+	if err := ToFrom(&dst, src, WithMapToStruct(), WithJSONTags()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	exp := JSONUser{Name: "Ada", Email: "ada@example.com", Dash: "dash"}
+	if diff := cmp.Diff(exp, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+
+	// The tag `-` names a field without JSON conventions.
+	dst = JSONUser{}
+	if err := ToFrom(&dst, src, WithMapToStruct(), WithTags("json")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	exp = JSONUser{Name: "Ada", Email: "ada@example.com", Password: "dash", Dash: "dash"}
+	if diff := cmp.Diff(exp, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+	dash := struct {
+		Dash string `assign:"-"`
+	}{}
+	if err := ToFrom(&dash, src, WithMapToStruct(), WithJSONTags()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if dash.Dash != "dash" {
+		t.Errorf("expected: %q but found: %q", "dash", dash.Dash)
+	}
+}
+
+type JSONUser struct {
+	Name     string `json:"name"`
+	Email    string `json:"email,omitempty"`
+	Password string `json:"-"`
+	Dash     string `json:"-,"`
+}
+
+func TestAssignTagDirectives(t *testing.T) {
+	t.Parallel()
+
+	src := map[string]interface{}{
+		"amount":  "1250",
+		"ratio":   "0.5",
+		"enabled": "true",
+		"count":   "7",
+		"total":   2.5,
+		"payload": "aGVsbG8=",
+		"created": "2020-01-02T03:04:05Z",
+		"timeout": "1m30s",
+	}
+	dst := Directives{}
+	if err := ToFrom(&dst, src, WithMapToStruct()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	count := 7
+	exp := Directives{
+		Amount:  1250,
+		Ratio:   0.5,
+		Enabled: true,
+		Count:   &count,
+		Total:   "2.5",
+		Payload: []byte("hello"),
+		Created: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		Timeout: 90 * time.Second,
+	}
+	if diff := cmp.Diff(exp, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+
+	t.Run("parse", func(t *testing.T) {
+		t.Parallel()
+		dst := Directives{}
+		expErr := ErrorParse{}
+		err := ToFrom(&dst, map[string]interface{}{"amount": "1.5"}, WithMapToStruct())
+		if !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+	t.Run("type", func(t *testing.T) {
+		t.Parallel()
+		dst := Directives{}
+		expErr := ErrorType{}
+		err := ToFrom(&dst, map[string]interface{}{"amount": 1}, WithMapToStruct())
+		if !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+}
+
+type Directives struct {
+	Amount  int           `assign:"amount,string"`
+	Ratio   float64       `assign:"ratio,string"`
+	Enabled bool          `assign:"enabled,string"`
+	Count   *int          `assign:"count,string"`
+	Total   string        `assign:"total,number"`
+	Payload []byte        `assign:"payload,base64"`
+	Created time.Time     `assign:"created,time"`
+	Timeout time.Duration `assign:"timeout,time"`
+}
+
+func TestAssignWithStructTagCache(t *testing.T) {
+	t.Parallel()
+
+	src := map[string]interface{}{
+		"name":     "Ada",
+		"email":    "ada@example.com",
+		"Name":     "Lovelace",
+		"password": "secret",
+	}
+	tests := []struct {
+		name    string
+		options []Option
+	}{
+		{name: "assign"},
+		{name: "json", options: []Option{WithJSONTags()}},
+		{name: "transform", options: []Option{WithJSONTags(), WithTagTransform(strings.ToUpper)}},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			options := append([]Option{WithMapToStruct()}, test.options...)
+			exp := JSONUser{}
+			if err := ToFrom(&exp, src, options...); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			// The second assignment reads the tags cached by the first.
+			for i := 0; i < 2; i++ {
+				dst := JSONUser{}
+				if err := ToFrom(&dst, src, append(options, WithStructTagCache())...); err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+				if diff := cmp.Diff(exp, dst); diff != "" {
+					t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+				}
+			}
+		})
+	}
+}
+
+// TestResetCache is not parallel so the caches are not populated by other tests while reset.
+func TestResetCache(t *testing.T) {
+	type Cached struct {
+		Name string `assign:"name,required"`
+	}
+	a := From(map[string]interface{}{"name": "Ada"}, WithMapToStruct(), WithStructTagCache())
+	key := cacheKey{typ: reflect.TypeOf(Cached{}), tags: a.tagKeys}
+	dst := Cached{}
+	if err := a.To(&dst); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if _, ok := tagCache.Load(key); !ok {
+		t.Errorf("expected cached tags of type: %v", key.typ)
+	}
+	ResetCache()
+	if _, ok := tagCache.Load(key); ok {
+		t.Errorf("expected no cached tags of type: %v", key.typ)
+	}
+	n := 0
+	scalarCache.Range(func(interface{}, interface{}) bool {
+		n++
+		return true
+	})
+	if n != 0 {
+		t.Errorf("expected scalar cache length: %d but found: %d", 0, n)
+	}
+}
+
+func BenchmarkAssignWithStructTagCache(b *testing.B) {
+	src := map[string]interface{}{"name": "Ada", "email": "ada@example.com"}
+	for _, bench := range []struct {
+		name    string
+		options []Option
+	}{
+		{name: "parsed", options: []Option{WithMapToStruct(), WithJSONTags()}},
+		{name: "cached", options: []Option{WithMapToStruct(), WithJSONTags(), WithStructTagCache()}},
+	} {
+		bench := bench
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				dst := JSONUser{}
+				if err := From(src, bench.options...).To(&dst); err != nil {
+					b.Fatalf("unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
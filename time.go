@@ -0,0 +1,104 @@
+package assign
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"time"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// assignTime assigns to a time.Time from times, strings or Unix timestamps.
+// Strings are parsed with the layouts in order, see WithTimeLayouts.
+// Integers are Unix timestamps in the unit of WithUnixTime.
+func (a *Assigner) assignTime(dt reflect.Value, st Source, md *metadata) error {
+	sv := a.valueOf(st)
+	var t time.Time
+	switch sk := sv.Kind(); sk {
+	case reflect.String:
+		var err error
+		if t, err = a.parseTime(sv.String()); err != nil {
+			return newErrorParse(dt.Type(), sv.String(), err)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if a.unixTime <= 0 {
+			return newError(dt.Type(), sk)
+		}
+		var ok bool
+		if t, ok = a.unixTimeOf(new(big.Int).SetInt64(sv.Int())); !ok {
+			return newErrorOverflow(dt.Type(), sv.Interface())
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if a.unixTime <= 0 {
+			return newError(dt.Type(), sk)
+		}
+		var ok bool
+		if t, ok = a.unixTimeOf(new(big.Int).SetUint64(sv.Uint())); !ok {
+			return newErrorOverflow(dt.Type(), sv.Interface())
+		}
+	default:
+		if sv.Type() != timeType {
+			return newError(dt.Type(), sk)
+		}
+		t = sv.Interface().(time.Time)
+	}
+	tv := reflect.ValueOf(t)
+	if a.unchanged(tv, md) {
+		md.stats.Skipped++
+		return nil
+	}
+	dt.Set(tv)
+	md.stats.Assigned++
+	return nil
+}
+
+// unixToInternal is the seconds from the zero time to the Unix epoch,
+// which bounds the seconds of a time.
+const unixToInternal int64 = 62135596800
+
+// unixTimeOf converts the Unix timestamp in the unit of WithUnixTime to a time.
+// The timestamp is split into whole seconds and the leftover nanoseconds
+// so it does not overflow as nanoseconds.
+// False is returned when the time is out of range.
+func (a *Assigner) unixTimeOf(ts *big.Int) (time.Time, bool) {
+	ns := ts.Mul(ts, big.NewInt(int64(a.unixTime)))
+	sec, nsec := ns.QuoRem(ns, big.NewInt(int64(time.Second)), new(big.Int))
+	if !sec.IsInt64() || sec.Int64() > math.MaxInt64-unixToInternal {
+		return time.Time{}, false
+	}
+	return time.Unix(sec.Int64(), nsec.Int64()), true
+}
+
+// parseTime parses the string with the first matching layout.
+// Times without a zone are in the location of WithTimeLocation, otherwise UTC.
+// The error of the last layout is returned when no layout matches.
+func (a *Assigner) parseTime(s string) (time.Time, error) {
+	var err error
+	for _, layout := range a.timeLayouts {
+		var t time.Time
+		if a.timeLoc != nil {
+			t, err = time.ParseInLocation(layout, s, a.timeLoc)
+		} else {
+			t, err = time.Parse(layout, s)
+		}
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// assignDuration assigns to a time.Duration from a string parsed by time.ParseDuration.
+func (a *Assigner) assignDuration(db, sv reflect.Value, md *metadata) error {
+	d, err := time.ParseDuration(sv.String())
+	if err != nil {
+		return newErrorParse(db.Type(), sv.String(), err)
+	}
+	db.SetInt(int64(d))
+	md.stats.Assigned++
+	return nil
+}
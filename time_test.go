@@ -0,0 +1,307 @@
+package assign
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAssignTime(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		src     interface{}
+		options []Option
+		exp     time.Time
+	}{
+		{
+			name: "time",
+			src:  time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC),
+			exp:  time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC),
+		},
+		{
+			name: "rfc3339",
+			src:  "2021-03-04T05:06:07Z",
+			exp:  time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC),
+		},
+		{
+			name:    "layout",
+			src:     "2021-03-04",
+			options: []Option{WithTimeLayouts("2006-01-02")},
+			exp:     time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "rfc3339 with layout",
+			src:     "2021-03-04T05:06:07Z",
+			options: []Option{WithTimeLayouts("2006-01-02")},
+			exp:     time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC),
+		},
+		{
+			name:    "unix seconds",
+			src:     int64(1614834367),
+			options: []Option{WithUnixTime(time.Second)},
+			exp:     time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC),
+		},
+		{
+			name:    "unix milliseconds",
+			src:     int64(1614834367001),
+			options: []Option{WithUnixTime(time.Millisecond)},
+			exp:     time.Date(2021, 3, 4, 5, 6, 7, int(time.Millisecond), time.UTC),
+		},
+		{
+			name:    "unix seconds beyond nanoseconds",
+			src:     int64(1e10),
+			options: []Option{WithUnixTime(time.Second)},
+			exp:     time.Date(2286, 11, 20, 17, 46, 40, 0, time.UTC),
+		},
+		{
+			name:    "unix negative seconds",
+			src:     int64(-1e10),
+			options: []Option{WithUnixTime(time.Second)},
+			exp:     time.Date(1653, 2, 10, 6, 13, 20, 0, time.UTC),
+		},
+		{
+			name:    "unix uint above max int",
+			src:     uint64(math.MaxInt64 + 1),
+			options: []Option{WithUnixTime(time.Nanosecond)},
+			exp:     time.Unix(9223372036, 854775808),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			dst := Schedule{}
+			src := ScheduleSource{Start: test.src}
+
+			if err := ToFrom(&dst, src, test.options...); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if !test.exp.Equal(dst.Start) {
+				t.Errorf("expected: %v but found: %v", test.exp, dst.Start)
+			}
+		})
+	}
+}
+
+func TestAssignTimeErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("parse", func(t *testing.T) {
+		t.Parallel()
+		var dst time.Time
+		expErr := ErrorParse{}
+		if err := ToFrom(&dst, "2021-03-04"); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+	t.Run("unix overflow", func(t *testing.T) {
+		t.Parallel()
+		var dst time.Time
+		expErr := ErrorOverflow{}
+		if err := ToFrom(&dst, int64(math.MaxInt64), WithUnixTime(time.Second)); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+	t.Run("unix uint overflow", func(t *testing.T) {
+		t.Parallel()
+		var dst time.Time
+		expErr := ErrorOverflow{}
+		if err := ToFrom(&dst, uint64(math.MaxUint64), WithUnixTime(time.Hour)); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+	t.Run("unix without option", func(t *testing.T) {
+		t.Parallel()
+		var dst time.Time
+		expErr := ErrorType{}
+		if err := ToFrom(&dst, 1614834367); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+}
+
+func TestAssignTimePointer(t *testing.T) {
+	t.Parallel()
+
+	exp := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	var dst *time.Time
+	if err := ToFrom(&dst, &exp); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if diff := cmp.Diff(&exp, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+}
+
+func TestAssignWithTimeLocation(t *testing.T) {
+	t.Parallel()
+
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("location unavailable: %v", err)
+	}
+	const layout = "2006-01-02 15:04:05"
+	tests := []struct {
+		name    string
+		src     string
+		options []Option
+		exp     time.Time
+	}{
+		{
+			name:    "location",
+			src:     "2021-03-04 05:06:07",
+			options: []Option{WithTimeLayouts(layout), WithTimeLocation(loc)},
+			exp:     time.Date(2021, 3, 4, 5, 6, 7, 0, loc),
+		},
+		{
+			name:    "utc",
+			src:     "2021-03-04 05:06:07",
+			options: []Option{WithTimeLayouts(layout)},
+			exp:     time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC),
+		},
+		{
+			name:    "zone",
+			src:     "2021-03-04T05:06:07Z",
+			options: []Option{WithTimeLayouts(layout), WithTimeLocation(loc)},
+			exp:     time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC),
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			dst := Schedule{}
+			if err := ToFrom(&dst, ScheduleSource{Start: test.src}, test.options...); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if !dst.Start.Equal(test.exp) {
+				t.Errorf("expected time: %v but found: %v", test.exp, dst.Start)
+			}
+			if loc := dst.Start.Location().String(); loc != test.exp.Location().String() {
+				t.Errorf("expected location: %q but found: %q", test.exp.Location(), loc)
+			}
+		})
+	}
+}
+
+type Schedule struct {
+	Start time.Time
+}
+
+// ScheduleSource has a start of any source to assign to a Schedule.
+type ScheduleSource struct {
+	Start interface{}
+}
+
+func TestAssignWithDurationParsing(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		src  interface{}
+		exp  time.Duration
+	}{
+		{
+			name: "string",
+			src:  "90s",
+			exp:  90 * time.Second,
+		},
+		{
+			name: "nanoseconds",
+			src:  int64(1500),
+			exp:  1500 * time.Nanosecond,
+		},
+		{
+			name: "duration",
+			src:  time.Minute,
+			exp:  time.Minute,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var dst time.Duration
+
+			if err := ToFrom(&dst, test.src, WithDurationParsing()); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if dst != test.exp {
+				t.Errorf("expected: %v but found: %v", test.exp, dst)
+			}
+		})
+	}
+}
+
+func TestAssignWithDurationParsingErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		var dst time.Duration
+		expErr := ErrorParse{}
+		if err := ToFrom(&dst, "ninety seconds", WithDurationParsing()); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+	t.Run("without option", func(t *testing.T) {
+		t.Parallel()
+		var dst time.Duration
+		expErr := ErrorType{}
+		if err := ToFrom(&dst, "90s"); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+}
+
+func TestAssignWithComposite(t *testing.T) {
+	t.Parallel()
+
+	combine := func(src Source) (interface{}, error) {
+		date, _ := src.FieldByName("Date").Interface().(string)
+		clock, _ := src.FieldByName("Time").Interface().(string)
+		return time.Parse("2006-01-02 15:04:05", date+" "+clock)
+	}
+	t.Run("combine", func(t *testing.T) {
+		t.Parallel()
+		src := []LegacyRecord{{Date: "2020-01-02", Time: "03:04:05"}}
+		var dst []Schedule
+		if err := ToFrom(&dst, src, WithComposite("[0].Start", combine)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := []Schedule{{Start: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)}}
+		if diff := cmp.Diff(exp, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		src := LegacyRecord{Date: "invalid"}
+		dst := Schedule{}
+		expErr := ErrorSource{}
+		if err := ToFrom(&dst, src, WithComposite("Start", combine)); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+			return
+		}
+		if expErr.Name != "Start" {
+			t.Errorf("expected name: %q but found: %q", "Start", expErr.Name)
+		}
+	})
+}
+
+type LegacyRecord struct {
+	Date string
+	Time string
+}
@@ -0,0 +1,27 @@
+package assign
+
+import (
+	"encoding"
+	"reflect"
+)
+
+var binaryUnmarshalerType = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+
+// binaryUnmarshaler provides the encoding.BinaryUnmarshaler of the addressable destination.
+// The result is false when the destination does not implement it with a pointer receiver.
+func binaryUnmarshaler(dv reflect.Value) (encoding.BinaryUnmarshaler, bool) {
+	if !dv.CanAddr() || !reflect.PtrTo(dv.Type()).Implements(binaryUnmarshalerType) {
+		return nil, false
+	}
+	return dv.Addr().Interface().(encoding.BinaryUnmarshaler), true
+}
+
+// assignBinary assigns a byte slice to the destination with UnmarshalBinary.
+// The error of UnmarshalBinary is returned as ErrorUnmarshal.
+func (a *Assigner) assignBinary(u encoding.BinaryUnmarshaler, dv reflect.Value, sv Source, md *metadata) error {
+	if err := u.UnmarshalBinary(a.valueOf(sv).Bytes()); err != nil {
+		return newErrorUnmarshal(dv.Type(), err)
+	}
+	md.stats.Assigned++
+	return nil
+}
@@ -0,0 +1,63 @@
+package assign
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAssignWithBinaryUnmarshaler(t *testing.T) {
+	t.Parallel()
+
+	id := UUID{0: 1, 15: 2}
+	t.Run("value", func(t *testing.T) {
+		t.Parallel()
+		src := struct{ ID, Ref []byte }{ID: id[:], Ref: id[:]}
+		dst := struct {
+			ID  UUID
+			Ref *UUID
+		}{}
+		if err := ToFrom(&dst, src, WithBinaryUnmarshaler()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if diff := cmp.Diff(id, dst.ID); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst.ID)
+		}
+		if dst.Ref == nil || *dst.Ref != id {
+			t.Errorf("expected reference: %v but found: %v", id, dst.Ref)
+		}
+	})
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		dst := UUID{}
+		expErr := ErrorUnmarshal{}
+		if err := ToFrom(&dst, []byte{1}, WithBinaryUnmarshaler()); !errors.As(err, &expErr) {
+			t.Errorf("expected type: %T but found: %T", expErr, err)
+		}
+	})
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+		dst := UUID{}
+		if err := ToFrom(&dst, []byte{1}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if diff := cmp.Diff(UUID{0: 1}, dst); diff != "" {
+			t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+		}
+	})
+}
+
+// UUID implements encoding.BinaryUnmarshaler.
+type UUID [16]byte
+
+func (u *UUID) UnmarshalBinary(b []byte) error {
+	if len(b) != len(u) {
+		return fmt.Errorf("invalid length: %d", len(b))
+	}
+	copy(u[:], b)
+	return nil
+}
@@ -0,0 +1,45 @@
+package assign
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// unexported provides a settable value of a field that is not exported.
+// The value must be addressable, otherwise it is returned as is.
+func unexported(v reflect.Value) reflect.Value {
+	if v.CanSet() || !v.CanAddr() {
+		return v
+	}
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
+
+// readable provides a value that can be interfaced from a field that is not exported.
+// Addressable values are accessed through their address,
+// otherwise basic values are copied.
+func readable(v reflect.Value) reflect.Value {
+	if v.CanInterface() {
+		return v
+	}
+	if v.CanAddr() {
+		return unexported(v)
+	}
+	cp := reflect.New(v.Type()).Elem()
+	switch v.Kind() {
+	case reflect.Bool:
+		cp.SetBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		cp.SetInt(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		cp.SetUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		cp.SetFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		cp.SetComplex(v.Complex())
+	case reflect.String:
+		cp.SetString(v.String())
+	default:
+		return v
+	}
+	return cp
+}
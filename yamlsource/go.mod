@@ -0,0 +1,9 @@
+module github.com/norunners/assign/yamlsource
+
+go 1.20
+
+require (
+	github.com/google/go-cmp v0.5.5
+	github.com/norunners/assign v0.0.0-20261016125326-23e6754a8e1f
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,183 @@
+// Package yamlsource provides an assign.Source of YAML nodes.
+// The node tree is navigated directly, which preserves the order of mappings
+// and the types of scalars, e.g. a quoted "1" is a string.
+package yamlsource
+
+import (
+	"reflect"
+
+	"github.com/norunners/assign"
+	"gopkg.in/yaml.v3"
+)
+
+// Of provides a Source from a YAML node.
+// Documents are their content and aliases are their anchored nodes.
+// YAML mappings are maps, see assign.WithMapToStruct to assign mappings to structs.
+// YAML sequences are slices.
+// YAML scalars are the Go values decoded by their tags, e.g. !!int is an int.
+func Of(node *yaml.Node) assign.Source {
+	for node != nil {
+		switch {
+		case node.Kind == yaml.DocumentNode && len(node.Content) > 0:
+			node = node.Content[0]
+			continue
+		case node.Kind == yaml.AliasNode && node.Alias != nil:
+			node = node.Alias
+			continue
+		}
+		break
+	}
+	return &source{node: node}
+}
+
+// Parse provides a Source from parsing the YAML.
+func Parse(data []byte) (assign.Source, error) {
+	node := &yaml.Node{}
+	if err := yaml.Unmarshal(data, node); err != nil {
+		return nil, err
+	}
+	return Of(node), nil
+}
+
+// source satisfies assign.Source for YAML nodes.
+type source struct {
+	node *yaml.Node
+	// val is the lazily decoded value of a scalar.
+	val     interface{}
+	decoded bool
+}
+
+func (v *source) Kind() reflect.Kind {
+	if v.node == nil {
+		return reflect.Invalid
+	}
+	switch v.node.Kind {
+	case yaml.MappingNode:
+		return reflect.Map
+	case yaml.SequenceNode:
+		return reflect.Slice
+	case yaml.ScalarNode:
+		if val := v.scalar(); val != nil {
+			return reflect.TypeOf(val).Kind()
+		}
+	}
+	return reflect.Invalid
+}
+
+// scalar provides the decoded value of the scalar, nil for null or scalars that fail to decode.
+func (v *source) scalar() interface{} {
+	if !v.decoded {
+		v.decoded = true
+		if err := v.node.Decode(&v.val); err != nil {
+			v.val = nil
+		}
+	}
+	return v.val
+}
+
+// Elem is the source itself since YAML has no pointers or interfaces.
+func (v *source) Elem() assign.Source {
+	return v
+}
+
+// FieldByName provides the value of the first key of the mapping that equals the name.
+func (v *source) FieldByName(name string) assign.Source {
+	if v.node == nil || v.node.Kind != yaml.MappingNode {
+		return Of(nil)
+	}
+	for i := 0; i+1 < len(v.node.Content); i += 2 {
+		if v.node.Content[i].Value == name {
+			return Of(v.node.Content[i+1])
+		}
+	}
+	return Of(nil)
+}
+
+// Fields provides the keys of the mapping in order.
+func (v *source) Fields() []string {
+	if v.node == nil || v.node.Kind != yaml.MappingNode {
+		return nil
+	}
+	names := make([]string, 0, len(v.node.Content)/2)
+	for i := 0; i+1 < len(v.node.Content); i += 2 {
+		names = append(names, v.node.Content[i].Value)
+	}
+	return names
+}
+
+func (v *source) Len() int {
+	if v.node == nil {
+		return 0
+	}
+	if v.node.Kind == yaml.MappingNode {
+		return len(v.node.Content) / 2
+	}
+	return len(v.node.Content)
+}
+
+func (v *source) Index(i int) assign.Source {
+	return Of(v.node.Content[i])
+}
+
+// Pointer is always zero since the nodes of aliases are shared without cyclical paths.
+func (v *source) Pointer() uintptr {
+	return 0
+}
+
+// MapRange provides an iterator of the mapping in order.
+func (v *source) MapRange() assign.MapIter {
+	return &mapIter{content: v.node.Content, i: -2}
+}
+
+// Skip handles nodes that do not exist, null and zero scalars.
+func (v *source) Skip() bool {
+	if v.node == nil {
+		return true
+	}
+	if v.node.Kind != yaml.ScalarNode {
+		return false
+	}
+	val := v.scalar()
+	return val == nil || reflect.ValueOf(val).IsZero()
+}
+
+func (v *source) Interface() interface{} {
+	if v.node == nil {
+		return nil
+	}
+	if v.node.Kind == yaml.ScalarNode {
+		return v.scalar()
+	}
+	var val interface{}
+	if err := v.node.Decode(&val); err != nil {
+		return nil
+	}
+	return val
+}
+
+var (
+	_ assign.Source  = (*source)(nil)
+	_ assign.Fielder = (*source)(nil)
+)
+
+// mapIter satisfies assign.MapIter for YAML mappings.
+// The content of mappings alternates between keys and values.
+type mapIter struct {
+	content []*yaml.Node
+	i       int
+}
+
+func (m *mapIter) Next() bool {
+	m.i += 2
+	return m.i+1 < len(m.content)
+}
+
+func (m *mapIter) Key() assign.Source {
+	return Of(m.content[m.i])
+}
+
+func (m *mapIter) Value() assign.Source {
+	return Of(m.content[m.i+1])
+}
+
+var _ assign.MapIter = (*mapIter)(nil)
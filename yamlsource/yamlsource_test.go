@@ -0,0 +1,103 @@
+package yamlsource
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/norunners/assign"
+)
+
+const service = `
+name: api
+replicas: 3
+version: "2"
+ratio: 0.5
+enabled: true
+owner: null
+database: &db
+  host: localhost
+  port: 5432
+replica: *db
+endpoints:
+  - path: /health
+    methods: [GET]
+  - path: /users
+    methods: [GET, POST]
+labels:
+  tier: backend
+  team: core
+`
+
+type Service struct {
+	Name      string            `yaml:"name"`
+	Replicas  int               `yaml:"replicas"`
+	Version   string            `yaml:"version"`
+	Ratio     float64           `yaml:"ratio"`
+	Enabled   bool              `yaml:"enabled"`
+	Owner     string            `yaml:"owner"`
+	Database  Database          `yaml:"database"`
+	Replica   *Database         `yaml:"replica"`
+	Endpoints []Endpoint        `yaml:"endpoints"`
+	Labels    map[string]string `yaml:"labels"`
+}
+
+type Database struct {
+	Host string `yaml:"host"`
+	Port int    `yaml:"port"`
+}
+
+type Endpoint struct {
+	Path    string   `yaml:"path"`
+	Methods []string `yaml:"methods"`
+}
+
+func TestSource(t *testing.T) {
+	t.Parallel()
+
+	src, err := Parse([]byte(service))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	dst := Service{Owner: "kept"}
+	if err := assign.ToFrom(&dst, src, assign.WithTags("yaml"), assign.WithMapToStruct()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	exp := Service{
+		Name:     "api",
+		Replicas: 3,
+		Version:  "2",
+		Ratio:    0.5,
+		Enabled:  true,
+		Owner:    "kept",
+		Database: Database{Host: "localhost", Port: 5432},
+		Replica:  &Database{Host: "localhost", Port: 5432},
+		Endpoints: []Endpoint{
+			{Path: "/health", Methods: []string{"GET"}},
+			{Path: "/users", Methods: []string{"GET", "POST"}},
+		},
+		Labels: map[string]string{"tier": "backend", "team": "core"},
+	}
+	if diff := cmp.Diff(exp, dst); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, dst)
+	}
+}
+
+func TestSourceOrder(t *testing.T) {
+	t.Parallel()
+
+	src, err := Parse([]byte(service))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	var keys []string
+	for mi := src.FieldByName("labels").MapRange(); mi.Next(); {
+		keys = append(keys, mi.Key().Interface().(string))
+	}
+	if diff := cmp.Diff([]string{"tier", "team"}, keys); diff != "" {
+		t.Errorf("(-expected +actual):\n%s\n%+v", diff, keys)
+	}
+}